@@ -0,0 +1,181 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// AngleMode is a unit of angles in the trigonometric functions;
+// functions wrapped by it don't share any state, so they may be called
+// concurrently.
+type AngleMode int
+
+// ...
+const (
+	RadianMode AngleMode = iota
+	DegreeMode
+	GradianMode
+)
+
+var (
+	directTrigonometricFunctions  = []string{"sin", "cos", "tan"}
+	inverseTrigonometricFunctions = []string{"asin", "acos", "atan", "atan2"}
+)
+
+// exact values of the direct trigonometric functions at quarter turns;
+// NaN means that the function is undefined
+var quarterTurnValues = map[string][4]float64{
+	"sin": {0, 1, 0, -1},
+	"cos": {1, 0, -1, 0},
+	"tan": {0, math.NaN(), 0, math.NaN()},
+}
+
+// ParseAngleMode parses the mode by its name: "rad", "deg" or "grad".
+func ParseAngleMode(name string) (AngleMode, error) {
+	switch name {
+	case "rad":
+		return RadianMode, nil
+	case "deg":
+		return DegreeMode, nil
+	case "grad":
+		return GradianMode, nil
+	default:
+		return 0, fmt.Errorf("unknown angle mode %q", name)
+	}
+}
+
+// String ...
+func (mode AngleMode) String() string {
+	switch mode {
+	case RadianMode:
+		return "rad"
+	case DegreeMode:
+		return "deg"
+	case GradianMode:
+		return "grad"
+	default:
+		return fmt.Sprintf("AngleMode(%d)", mode)
+	}
+}
+
+// WrapFunctions returns a new group, in which the trigonometric functions
+// accept and return angles in units of the mode.
+func (mode AngleMode) WrapFunctions(
+	functions models.FunctionGroup,
+) models.FunctionGroup {
+	if mode == RadianMode {
+		return functions
+	}
+
+	wrappedFunctions := functions.Merge(nil)
+	for _, name := range directTrigonometricFunctions {
+		if function, ok := functions[name]; ok && function.Handler != nil {
+			wrappedFunctions[name] = mode.wrapDirectFunction(name, function)
+		}
+	}
+	for _, name := range inverseTrigonometricFunctions {
+		if function, ok := functions[name]; ok && function.Handler != nil {
+			wrappedFunctions[name] = mode.wrapInverseFunction(function)
+		}
+	}
+	if function, ok := functions["diff"]; ok && function.ValueHandler != nil {
+		wrappedFunctions["diff"] = wrapSymbolicFunction(function)
+	}
+
+	return wrappedFunctions
+}
+
+func (mode AngleMode) fullTurn() float64 {
+	switch mode {
+	case DegreeMode:
+		return 360
+	case GradianMode:
+		return 400
+	default:
+		return 2 * math.Pi
+	}
+}
+
+func (mode AngleMode) wrapDirectFunction(
+	name string,
+	function models.Function,
+) models.Function {
+	handler := function.Handler
+	function.Handler = func(arguments []float64) (float64, error) {
+		// the reduction is exact in units of the mode, unlike in radians
+		angle := math.Mod(arguments[0], mode.fullTurn())
+		if angle < 0 {
+			angle += mode.fullTurn()
+		}
+
+		quarterTurns := angle / (mode.fullTurn() / 4)
+		if values, ok := quarterTurnValues[name]; ok &&
+			quarterTurns == math.Trunc(quarterTurns) {
+			value := values[int(quarterTurns)]
+			if math.IsNaN(value) {
+				return 0, errors.New("function is undefined for argument #0")
+			}
+
+			return value, nil
+		}
+
+		return handler([]float64{angle / mode.fullTurn() * 2 * math.Pi})
+	}
+
+	return function
+}
+
+func (mode AngleMode) wrapInverseFunction(function models.Function) models.Function {
+	handler := function.Handler
+	function.Handler = func(arguments []float64) (float64, error) {
+		result, err := handler(arguments)
+		if err != nil {
+			return 0, err
+		}
+
+		return result / (2 * math.Pi) * mode.fullTurn(), nil
+	}
+
+	return function
+}
+
+// symbolic derivatives of the trigonometric functions are in radians,
+// so they are forbidden in other modes
+func wrapSymbolicFunction(function models.Function) models.Function {
+	handler := function.ValueHandler
+	function.ValueHandler = func(arguments []models.Value) (models.Value, error) {
+		if arguments[0].Kind == models.ExpressionValue {
+			for _, command := range arguments[0].Expression.Commands {
+				if command.Kind == models.CallFunctionCommand &&
+					isTrigonometricFunction(command.Operand) {
+					return models.Value{}, fmt.Errorf(
+						"function %s is differentiated only in the radian mode",
+						command.Operand,
+					)
+				}
+			}
+		}
+
+		return handler(arguments)
+	}
+
+	return function
+}
+
+func isTrigonometricFunction(name string) bool {
+	for _, names := range [][]string{
+		directTrigonometricFunctions,
+		inverseTrigonometricFunctions,
+	} {
+		for _, trigonometricName := range names {
+			if name == trigonometricName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
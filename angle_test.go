@@ -0,0 +1,314 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAngleMode(test *testing.T) {
+	type args struct {
+		name string
+	}
+
+	testsCases := []struct {
+		name     string
+		args     args
+		wantMode AngleMode
+		wantErr  string
+	}{
+		{
+			name:     "radians",
+			args:     args{name: "rad"},
+			wantMode: RadianMode,
+			wantErr:  "",
+		},
+		{
+			name:     "degrees",
+			args:     args{name: "deg"},
+			wantMode: DegreeMode,
+			wantErr:  "",
+		},
+		{
+			name:     "gradians",
+			args:     args{name: "grad"},
+			wantMode: GradianMode,
+			wantErr:  "",
+		},
+		{
+			name:     "error",
+			args:     args{name: "turn"},
+			wantMode: 0,
+			wantErr:  `unknown angle mode "turn"`,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotMode, gotErr := ParseAngleMode(testCase.args.name)
+
+			assert.Equal(test, testCase.wantMode, gotMode)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+				assert.Equal(test, testCase.args.name, gotMode.String())
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestAngleMode_WrapFunctions(test *testing.T) {
+	type args struct {
+		mode      AngleMode
+		name      string
+		arguments []float64
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantResult float64
+		wantErr    string
+	}{
+		{
+			name: "radians",
+			args: args{
+				mode:      RadianMode,
+				name:      "sin",
+				arguments: []float64{math.Pi / 6},
+			},
+			wantResult: 0.5,
+			wantErr:    "",
+		},
+		{
+			name: "degrees/direct function",
+			args: args{
+				mode:      DegreeMode,
+				name:      "sin",
+				arguments: []float64{30},
+			},
+			wantResult: 0.5,
+			wantErr:    "",
+		},
+		{
+			name: "degrees/direct function with a negative angle",
+			args: args{
+				mode:      DegreeMode,
+				name:      "tan",
+				arguments: []float64{-45},
+			},
+			wantResult: -1,
+			wantErr:    "",
+		},
+		{
+			name: "degrees/direct function with a quarter turn",
+			args: args{
+				mode:      DegreeMode,
+				name:      "cos",
+				arguments: []float64{90},
+			},
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "degrees/direct function with few turns",
+			args: args{
+				mode:      DegreeMode,
+				name:      "sin",
+				arguments: []float64{-630},
+			},
+			wantResult: 1,
+			wantErr:    "",
+		},
+		{
+			name: "degrees/inverse function",
+			args: args{
+				mode:      DegreeMode,
+				name:      "acos",
+				arguments: []float64{0.5},
+			},
+			wantResult: 60,
+			wantErr:    "",
+		},
+		{
+			name: "degrees/inverse function with two arguments",
+			args: args{
+				mode:      DegreeMode,
+				name:      "atan2",
+				arguments: []float64{1, -1},
+			},
+			wantResult: 135,
+			wantErr:    "",
+		},
+		{
+			name: "gradians/direct function",
+			args: args{
+				mode:      GradianMode,
+				name:      "cos",
+				arguments: []float64{50},
+			},
+			wantResult: math.Sqrt2 / 2,
+			wantErr:    "",
+		},
+		{
+			name: "gradians/inverse function",
+			args: args{
+				mode:      GradianMode,
+				name:      "atan",
+				arguments: []float64{1},
+			},
+			wantResult: 50,
+			wantErr:    "",
+		},
+		{
+			name: "error with an undefined value",
+			args: args{
+				mode:      DegreeMode,
+				name:      "tan",
+				arguments: []float64{270},
+			},
+			wantResult: 0,
+			wantErr:    "function is undefined for argument #0",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			functions := testCase.args.mode.WrapFunctions(BuiltInFunctions)
+			gotFunction, gotOk := functions[testCase.args.name]
+			require.True(test, gotOk)
+
+			gotResult, gotErr := gotFunction.Handler(testCase.args.arguments)
+
+			assert.InDelta(test, testCase.wantResult, gotResult, 1e-6)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestInterpreter_withAngleMode(test *testing.T) {
+	type args struct {
+		mode   AngleMode
+		inputs []string
+	}
+
+	testsCases := []struct {
+		name      string
+		args      args
+		wantMode  AngleMode
+		wantValue models.Value
+		wantErr   string
+	}{
+		{
+			name: "success with the initial mode",
+			args: args{
+				mode:   DegreeMode,
+				inputs: []string{"asin(1)"},
+			},
+			wantMode:  DegreeMode,
+			wantValue: models.NewNumber(90),
+			wantErr:   "",
+		},
+		{
+			name: "success with the mode statement",
+			args: args{
+				mode:   RadianMode,
+				inputs: []string{"mode grad", "x = cos(200)", "mode rad", "x + sin(0)"},
+			},
+			wantMode:  RadianMode,
+			wantValue: models.NewNumber(-1),
+			wantErr:   "",
+		},
+		{
+			name: "success with the mode function",
+			args: args{
+				mode:   RadianMode,
+				inputs: []string{"mode(2)"},
+			},
+			wantMode:  RadianMode,
+			wantValue: models.NewNumber(4),
+			wantErr:   "",
+		},
+		{
+			name: "success with the derivative in the radian mode",
+			args: args{
+				mode:   RadianMode,
+				inputs: []string{"diff(sin(x), x)"},
+			},
+			wantMode:  RadianMode,
+			wantValue: models.NewString("cos(x)"),
+			wantErr:   "",
+		},
+		{
+			name: "success with the derivative without trigonometric functions",
+			args: args{
+				mode:   DegreeMode,
+				inputs: []string{"diff(x^2, x)"},
+			},
+			wantMode:  DegreeMode,
+			wantValue: models.NewString("2 * x"),
+			wantErr:   "",
+		},
+		{
+			name: "error with an unknown mode",
+			args: args{
+				mode:   DegreeMode,
+				inputs: []string{"mode turn"},
+			},
+			wantMode:  DegreeMode,
+			wantValue: models.Value{},
+			wantErr: "unable to set the mode: " +
+				`unable to parse the angle mode: unknown angle mode "turn"`,
+		},
+		{
+			name: "error with the derivative in the degree mode",
+			args: args{
+				mode:   RadianMode,
+				inputs: []string{"mode deg", "diff(x * sin(x), x)"},
+			},
+			wantMode:  DegreeMode,
+			wantValue: models.Value{},
+			wantErr: "unable to calculate the code: " +
+				"unable to evaluate the commands: " +
+				"unable to call the function from command " +
+				"{Kind:2 Operand:diff ArgumentCount:2} with number #9: " +
+				"function sin is differentiated only in the radian mode",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotErr := models.Value{}, error(nil)
+
+			functions := BuiltInFunctions.Merge(models.FunctionGroup{
+				"mode": {
+					Arity: 1,
+					Handler: func(arguments []float64) (float64, error) {
+						return arguments[0] * arguments[0], nil
+					},
+				},
+			})
+			interpreter := NewInterpreter(models.VariableGroup{}, functions).
+				WithAngleMode(testCase.args.mode)
+			for _, input := range testCase.args.inputs {
+				gotValue, gotErr = interpreter.Interpret(input)
+				if gotErr != nil && gotErr != ErrNoValue {
+					break
+				}
+			}
+
+			assert.Equal(test, testCase.wantMode, interpreter.AngleMode())
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
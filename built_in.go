@@ -1,14 +1,28 @@
 package calculator
 
 import (
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/irenicaa/go-calculator/v2/models"
 )
 
+// BuiltInVariables contains the values of BuiltInConstants as ordinary
+// variables, so they may be reassigned.
+//
+// Deprecated: pass Constants(BuiltInConstants) to NewInterpreter instead,
+// so the constants can't be reassigned.
+var BuiltInVariables = BuiltInConstants.Variables()
+
 // ...
 var (
-	BuiltInVariables = models.VariableGroup{"pi": math.Pi, "e": math.E}
+	BuiltInConstants = models.ConstantGroup{
+		"pi": models.NewNumber(math.Pi),
+		"e":  models.NewNumber(math.E),
+	}
 	BuiltInFunctions = models.FunctionGroup{
 		// operators
 		"+": {
@@ -16,36 +30,42 @@ var (
 			Handler: func(arguments []float64) (float64, error) {
 				return arguments[0] + arguments[1], nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"-": {
 			Arity: 2,
 			Handler: func(arguments []float64) (float64, error) {
 				return arguments[0] - arguments[1], nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"*": {
 			Arity: 2,
 			Handler: func(arguments []float64) (float64, error) {
 				return arguments[0] * arguments[1], nil
 			},
+			DimensionHandler: productDimension,
 		},
 		"/": {
 			Arity: 2,
 			Handler: func(arguments []float64) (float64, error) {
 				return arguments[0] / arguments[1], nil
 			},
+			DimensionHandler: quotientDimension,
 		},
 		"%": {
 			Arity: 2,
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Mod(arguments[0], arguments[1]), nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"^": {
 			Arity: 2,
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Pow(arguments[0], arguments[1]), nil
 			},
+			DimensionHandler: powerDimension,
 		},
 
 		// functions
@@ -54,24 +74,28 @@ var (
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Floor(arguments[0]), nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"ceil": {
 			Arity: 1,
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Ceil(arguments[0]), nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"trunc": {
 			Arity: 1,
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Trunc(arguments[0]), nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"round": {
 			Arity: 1,
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Round(arguments[0]), nil
 			},
+			DimensionHandler: sameDimension,
 		},
 		"sin": {
 			Arity: 1,
@@ -120,6 +144,7 @@ var (
 			Handler: func(arguments []float64) (float64, error) {
 				return math.Sqrt(arguments[0]), nil
 			},
+			DimensionHandler: squareRootDimension,
 		},
 		"exp": {
 			Arity: 1,
@@ -127,22 +152,238 @@ var (
 				return math.Exp(arguments[0]), nil
 			},
 		},
-		"log": {
+		"log":   {Arity: 1, MaxArity: 2, Handler: logarithm},
+		"log2":  {Arity: 1, Handler: log2},
+		"log10": {Arity: 1, Handler: log10},
+		"abs": {
 			Arity: 1,
 			Handler: func(arguments []float64) (float64, error) {
-				return math.Log(arguments[0]), nil
+				return math.Abs(arguments[0]), nil
 			},
+			DimensionHandler: sameDimension,
 		},
-		"log10": {
+		"cbrt": {
 			Arity: 1,
 			Handler: func(arguments []float64) (float64, error) {
-				return math.Log10(arguments[0]), nil
+				return math.Cbrt(arguments[0]), nil
 			},
 		},
-		"abs": {
+		"sign": {Arity: 1, Handler: sign},
+		"clamp": {
+			Arity:            3,
+			Handler:          clamp,
+			DimensionHandler: sameDimension,
+		},
+		"deg": {
 			Arity: 1,
 			Handler: func(arguments []float64) (float64, error) {
-				return math.Abs(arguments[0]), nil
+				return arguments[0] * 180 / math.Pi, nil
+			},
+		},
+		"rad": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] * math.Pi / 180, nil
+			},
+		},
+
+		// hyperbolic functions
+		"sinh": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return math.Sinh(arguments[0]), nil
+			},
+		},
+		"cosh": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return math.Cosh(arguments[0]), nil
+			},
+		},
+		"tanh": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return math.Tanh(arguments[0]), nil
+			},
+		},
+		"asinh": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return math.Asinh(arguments[0]), nil
+			},
+		},
+		"acosh": {Arity: 1, Handler: acosh},
+		"atanh": {Arity: 1, Handler: atanh},
+
+		// special functions
+		"gamma":  {Arity: 1, Handler: gamma},
+		"lgamma": {Arity: 1, Handler: lgamma},
+		"erf": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return math.Erf(arguments[0]), nil
+			},
+		},
+		"erfc": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return math.Erfc(arguments[0]), nil
+			},
+		},
+
+		// integer functions
+		"factorial": {Arity: 1, Handler: factorial},
+		"binomial":  {Arity: 2, Handler: binomial},
+		"gcd":       {Arity: 2, Handler: gcd},
+		"lcm":       {Arity: 2, Handler: lcm},
+
+		// random numbers
+		"rand":    defaultRandomFunctions["rand"],
+		"randint": defaultRandomFunctions["randint"],
+		"normal":  defaultRandomFunctions["normal"],
+		"seed":    defaultRandomFunctions["seed"],
+
+		// arrays
+		"length": {
+			Arity: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				err := models.CheckArgumentKinds(arguments, models.ArrayValue)
+				if err != nil {
+					return models.Value{}, err
+				}
+
+				return models.NewNumber(float64(len(arguments[0].Elements))), nil
+			},
+		},
+
+		// statistics
+		"mean":       {Arity: 1, Variadic: true, ValueHandler: mean},
+		"median":     {Arity: 1, Variadic: true, ValueHandler: median},
+		"mode":       {Arity: 1, Variadic: true, ValueHandler: statisticalMode},
+		"variance":   {Arity: 1, Variadic: true, ValueHandler: variance},
+		"pvariance":  {Arity: 1, Variadic: true, ValueHandler: populationVariance},
+		"stddev":     {Arity: 1, Variadic: true, ValueHandler: standardDeviation},
+		"pstddev":    {Arity: 1, Variadic: true, ValueHandler: populationStandardDeviation},
+		"percentile": {Arity: 2, Variadic: true, ValueHandler: percentile},
+		"min":        {Arity: 1, Variadic: true, ValueHandler: minimum},
+		"max":        {Arity: 1, Variadic: true, ValueHandler: maximum},
+
+		// vectors and matrices
+		"dot":       {Arity: 2, ValueHandler: dot},
+		"cross":     {Arity: 2, ValueHandler: cross},
+		"norm":      {Arity: 1, ValueHandler: norm},
+		"transpose": {Arity: 1, ValueHandler: transpose},
+		"det":       {Arity: 1, ValueHandler: det},
+		"inv":       {Arity: 1, ValueHandler: inv},
+
+		// equations; solve(A, b) also solves linear systems
+		"solve": {
+			Arity:               2,
+			MaxArity:            3,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			BoundVariableArity:  3,
+			ValueHandler:        solveEquation,
+		},
+		"bisect": {
+			Arity:               4,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        bisect,
+		},
+		"minimize": {
+			Arity:               4,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        minimize,
+		},
+
+		// calculus
+		"deriv": {
+			Arity:               3,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        differentiate,
+		},
+		"diff": {
+			Arity:               2,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        differentiateSymbolically,
+		},
+		"integrate": {
+			Arity:               4,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        integrate,
+		},
+		"sum": {
+			Arity:               4,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        sumSeries,
+		},
+		"prod": {
+			Arity:               4,
+			LazyArgumentCount:   2,
+			BoundVariableNumber: 2,
+			ValueHandler:        multiplySeries,
+		},
+
+		// strings
+		"concat": {
+			Arity: 2,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				err := models.CheckArgumentKinds(
+					arguments,
+					models.StringValue,
+					models.StringValue,
+				)
+				if err != nil {
+					return models.Value{}, err
+				}
+
+				return models.NewString(arguments[0].Text + arguments[1].Text), nil
+			},
+		},
+		"len": {
+			Arity: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				err := models.CheckArgumentKinds(arguments, models.StringValue)
+				if err != nil {
+					return models.Value{}, err
+				}
+
+				length := utf8.RuneCountInString(arguments[0].Text)
+				return models.NewNumber(float64(length)), nil
+			},
+		},
+		"str": {
+			Arity: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				err := models.CheckArgumentKinds(arguments, models.NumberValue)
+				if err != nil {
+					return models.Value{}, err
+				}
+
+				return models.NewString(arguments[0].String()), nil
+			},
+		},
+		"num": {
+			Arity: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				err := models.CheckArgumentKinds(arguments, models.StringValue)
+				if err != nil {
+					return models.Value{}, err
+				}
+
+				text := strings.TrimSpace(arguments[0].Text)
+				number, err := strconv.ParseFloat(text, 64)
+				if err != nil {
+					return models.Value{}, fmt.Errorf("unable to parse the number: %w", err)
+				}
+
+				return models.NewNumber(number), nil
 			},
 		},
 	}
@@ -4,11 +4,16 @@ import (
 	"math"
 	"testing"
 
+	"github.com/irenicaa/go-calculator/v2/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestBuiltInVariables(test *testing.T) {
+	assert.Equal(test, BuiltInConstants.Variables(), BuiltInVariables)
+}
+
+func TestBuiltInConstants(test *testing.T) {
 	type args struct {
 		name string
 	}
@@ -35,7 +40,7 @@ func TestBuiltInVariables(test *testing.T) {
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
-			gotResult := BuiltInVariables[testCase.args.name]
+			gotResult := BuiltInConstants[testCase.args.name].Number
 
 			assert.InDelta(test, testCase.wantResult, gotResult, 1e-6)
 		})
@@ -318,6 +323,56 @@ func TestBuiltInFunctions(test *testing.T) {
 			wantResult: 0.693147,
 			wantErr:    "",
 		},
+		{
+			name: "log/base",
+			args: args{
+				name:      "log",
+				arguments: []float64{8, 2},
+			},
+			wantArity:  1,
+			wantResult: 3,
+			wantErr:    "",
+		},
+		{
+			name: "log/error with a negative number",
+			args: args{
+				name:      "log",
+				arguments: []float64{-2},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 should be at least 0",
+		},
+		{
+			name: "log/error with an incorrect base",
+			args: args{
+				name:      "log",
+				arguments: []float64{8, 1},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #1 should be positive and not equal to 1",
+		},
+		{
+			name: "log2",
+			args: args{
+				name:      "log2",
+				arguments: []float64{8},
+			},
+			wantArity:  1,
+			wantResult: 3,
+			wantErr:    "",
+		},
+		{
+			name: "log2/error",
+			args: args{
+				name:      "log2",
+				arguments: []float64{-8},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 should be at least 0",
+		},
 		{
 			name: "log10",
 			args: args{
@@ -328,6 +383,16 @@ func TestBuiltInFunctions(test *testing.T) {
 			wantResult: 0.30103,
 			wantErr:    "",
 		},
+		{
+			name: "log10/error",
+			args: args{
+				name:      "log10",
+				arguments: []float64{-1},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 should be at least 0",
+		},
 		{
 			name: "abs/positive",
 			args: args{
@@ -348,16 +413,1454 @@ func TestBuiltInFunctions(test *testing.T) {
 			wantResult: 2,
 			wantErr:    "",
 		},
-	}
-	for _, testCase := range testsCases {
-		test.Run(testCase.name, func(test *testing.T) {
-			gotFunction, gotOk := BuiltInFunctions[testCase.args.name]
-			require.True(test, gotOk)
-
-			gotResult, gotErr := gotFunction.Handler(testCase.args.arguments)
-
-			assert.Equal(test, testCase.wantArity, gotFunction.Arity)
-			assert.InDelta(test, testCase.wantResult, gotResult, 1e-6)
+		{
+			name: "cbrt",
+			args: args{
+				name:      "cbrt",
+				arguments: []float64{-8},
+			},
+			wantArity:  1,
+			wantResult: -2,
+			wantErr:    "",
+		},
+		{
+			name: "sign/positive",
+			args: args{
+				name:      "sign",
+				arguments: []float64{2.5},
+			},
+			wantArity:  1,
+			wantResult: 1,
+			wantErr:    "",
+		},
+		{
+			name: "sign/negative",
+			args: args{
+				name:      "sign",
+				arguments: []float64{-2.5},
+			},
+			wantArity:  1,
+			wantResult: -1,
+			wantErr:    "",
+		},
+		{
+			name: "sign/zero",
+			args: args{
+				name:      "sign",
+				arguments: []float64{0},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "clamp/inside",
+			args: args{
+				name:      "clamp",
+				arguments: []float64{5, 2, 10},
+			},
+			wantArity:  3,
+			wantResult: 5,
+			wantErr:    "",
+		},
+		{
+			name: "clamp/below",
+			args: args{
+				name:      "clamp",
+				arguments: []float64{1, 2, 10},
+			},
+			wantArity:  3,
+			wantResult: 2,
+			wantErr:    "",
+		},
+		{
+			name: "clamp/above",
+			args: args{
+				name:      "clamp",
+				arguments: []float64{12, 2, 10},
+			},
+			wantArity:  3,
+			wantResult: 10,
+			wantErr:    "",
+		},
+		{
+			name: "clamp/error",
+			args: args{
+				name:      "clamp",
+				arguments: []float64{5, 10, 2},
+			},
+			wantArity:  3,
+			wantResult: 0,
+			wantErr:    "argument #1 shouldn't be greater than argument #2",
+		},
+		{
+			name: "deg",
+			args: args{
+				name:      "deg",
+				arguments: []float64{math.Pi},
+			},
+			wantArity:  1,
+			wantResult: 180,
+			wantErr:    "",
+		},
+		{
+			name: "rad",
+			args: args{
+				name:      "rad",
+				arguments: []float64{180},
+			},
+			wantArity:  1,
+			wantResult: math.Pi,
+			wantErr:    "",
+		},
+		{
+			name: "sinh",
+			args: args{
+				name:      "sinh",
+				arguments: []float64{1},
+			},
+			wantArity:  1,
+			wantResult: 1.175201,
+			wantErr:    "",
+		},
+		{
+			name: "cosh",
+			args: args{
+				name:      "cosh",
+				arguments: []float64{1},
+			},
+			wantArity:  1,
+			wantResult: 1.543081,
+			wantErr:    "",
+		},
+		{
+			name: "tanh",
+			args: args{
+				name:      "tanh",
+				arguments: []float64{1},
+			},
+			wantArity:  1,
+			wantResult: 0.761594,
+			wantErr:    "",
+		},
+		{
+			name: "asinh",
+			args: args{
+				name:      "asinh",
+				arguments: []float64{1},
+			},
+			wantArity:  1,
+			wantResult: 0.881374,
+			wantErr:    "",
+		},
+		{
+			name: "acosh",
+			args: args{
+				name:      "acosh",
+				arguments: []float64{2},
+			},
+			wantArity:  1,
+			wantResult: 1.316958,
+			wantErr:    "",
+		},
+		{
+			name: "acosh/error",
+			args: args{
+				name:      "acosh",
+				arguments: []float64{0.5},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 should be at least 1",
+		},
+		{
+			name: "atanh",
+			args: args{
+				name:      "atanh",
+				arguments: []float64{0.5},
+			},
+			wantArity:  1,
+			wantResult: 0.549306,
+			wantErr:    "",
+		},
+		{
+			name: "atanh/error",
+			args: args{
+				name:      "atanh",
+				arguments: []float64{2},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 should be at most 1",
+		},
+		{
+			name: "gamma",
+			args: args{
+				name:      "gamma",
+				arguments: []float64{5},
+			},
+			wantArity:  1,
+			wantResult: 24,
+			wantErr:    "",
+		},
+		{
+			name: "gamma/fraction",
+			args: args{
+				name:      "gamma",
+				arguments: []float64{0.5},
+			},
+			wantArity:  1,
+			wantResult: 1.772454,
+			wantErr:    "",
+		},
+		{
+			name: "gamma/error",
+			args: args{
+				name:      "gamma",
+				arguments: []float64{-2},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 shouldn't be a non-positive integer",
+		},
+		{
+			name: "lgamma",
+			args: args{
+				name:      "lgamma",
+				arguments: []float64{10},
+			},
+			wantArity:  1,
+			wantResult: 12.801827,
+			wantErr:    "",
+		},
+		{
+			name: "lgamma/error",
+			args: args{
+				name:      "lgamma",
+				arguments: []float64{0},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 shouldn't be a non-positive integer",
+		},
+		{
+			name: "erf",
+			args: args{
+				name:      "erf",
+				arguments: []float64{0.5},
+			},
+			wantArity:  1,
+			wantResult: 0.5205,
+			wantErr:    "",
+		},
+		{
+			name: "erfc",
+			args: args{
+				name:      "erfc",
+				arguments: []float64{0.5},
+			},
+			wantArity:  1,
+			wantResult: 0.4795,
+			wantErr:    "",
+		},
+		{
+			name: "factorial",
+			args: args{
+				name:      "factorial",
+				arguments: []float64{5},
+			},
+			wantArity:  1,
+			wantResult: 120,
+			wantErr:    "",
+		},
+		{
+			name: "factorial/zero",
+			args: args{
+				name:      "factorial",
+				arguments: []float64{0},
+			},
+			wantArity:  1,
+			wantResult: 1,
+			wantErr:    "",
+		},
+		{
+			name: "factorial/overflow",
+			args: args{
+				name:      "factorial",
+				arguments: []float64{171},
+			},
+			wantArity:  1,
+			wantResult: math.Inf(1),
+			wantErr:    "",
+		},
+		{
+			name: "factorial/error with a fraction",
+			args: args{
+				name:      "factorial",
+				arguments: []float64{2.5},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 isn't an integer",
+		},
+		{
+			name: "factorial/error with a negative number",
+			args: args{
+				name:      "factorial",
+				arguments: []float64{-1},
+			},
+			wantArity:  1,
+			wantResult: 0,
+			wantErr:    "argument #0 should be at least 0",
+		},
+		{
+			name: "binomial",
+			args: args{
+				name:      "binomial",
+				arguments: []float64{10, 3},
+			},
+			wantArity:  2,
+			wantResult: 120,
+			wantErr:    "",
+		},
+		{
+			name: "binomial/greater subset",
+			args: args{
+				name:      "binomial",
+				arguments: []float64{3, 10},
+			},
+			wantArity:  2,
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "binomial/error",
+			args: args{
+				name:      "binomial",
+				arguments: []float64{10, -3},
+			},
+			wantArity:  2,
+			wantResult: 0,
+			wantErr:    "argument #1 should be at least 0",
+		},
+		{
+			name: "gcd",
+			args: args{
+				name:      "gcd",
+				arguments: []float64{12, -18},
+			},
+			wantArity:  2,
+			wantResult: 6,
+			wantErr:    "",
+		},
+		{
+			name: "gcd/zeros",
+			args: args{
+				name:      "gcd",
+				arguments: []float64{0, 0},
+			},
+			wantArity:  2,
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "gcd/error",
+			args: args{
+				name:      "gcd",
+				arguments: []float64{12, 1.5},
+			},
+			wantArity:  2,
+			wantResult: 0,
+			wantErr:    "argument #1 isn't an integer",
+		},
+		{
+			name: "lcm",
+			args: args{
+				name:      "lcm",
+				arguments: []float64{4, -6},
+			},
+			wantArity:  2,
+			wantResult: 12,
+			wantErr:    "",
+		},
+		{
+			name: "lcm/zero",
+			args: args{
+				name:      "lcm",
+				arguments: []float64{0, 6},
+			},
+			wantArity:  2,
+			wantResult: 0,
+			wantErr:    "",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotFunction, gotOk := BuiltInFunctions[testCase.args.name]
+			require.True(test, gotOk)
+
+			gotResult, gotErr := gotFunction.Handler(testCase.args.arguments)
+
+			assert.Equal(test, testCase.wantArity, gotFunction.Arity)
+			assert.InDelta(test, testCase.wantResult, gotResult, 1e-6)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuiltInFunctions_withValues(test *testing.T) {
+	meter := models.NewBaseDimension(models.LengthDimension)
+	second := models.NewBaseDimension(models.TimeDimension)
+
+	type args struct {
+		name      string
+		arguments []models.Value
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantArity  int
+		wantResult models.Value
+		wantErr    string
+	}{
+		// arrays
+		{
+			name: "length/success",
+			args: args{
+				name: "length",
+				arguments: []models.Value{
+					models.NewArray(models.NewNumber(2), models.NewNumber(3)),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "length/error",
+			args: args{
+				name:      "length",
+				arguments: []models.Value{models.NewNumber(2)},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 has type number, but array is expected",
+		},
+
+		// statistics
+		{
+			name: "mean/numbers",
+			args: args{
+				name: "mean",
+				arguments: []models.Value{
+					models.NewNumber(1),
+					models.NewNumber(2),
+					models.NewNumber(3),
+					models.NewNumber(4),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2.5),
+			wantErr:    "",
+		},
+		{
+			name: "mean/array",
+			args: args{
+				name: "mean",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(1),
+						models.NewNumber(2),
+						models.NewNumber(3),
+						models.NewNumber(4),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2.5),
+			wantErr:    "",
+		},
+		{
+			name: "mean/error with an empty array",
+			args: args{
+				name: "mean",
+				arguments: []models.Value{
+					models.NewArray(),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "0 numbers are given, but at least 1 are expected",
+		},
+		{
+			name: "mean/error with the argument kind",
+			args: args{
+				name: "mean",
+				arguments: []models.Value{
+					models.NewNumber(1),
+					models.NewArray(models.NewString("test")),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #1 has type string, but number is expected",
+		},
+		{
+			name: "median/odd count",
+			args: args{
+				name: "median",
+				arguments: []models.Value{
+					models.NewNumber(3),
+					models.NewNumber(1),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "median/even count",
+			args: args{
+				name: "median",
+				arguments: []models.Value{
+					models.NewNumber(4),
+					models.NewNumber(1),
+					models.NewNumber(3),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2.5),
+			wantErr:    "",
+		},
+		{
+			name: "mode/single",
+			args: args{
+				name: "mode",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(3),
+						models.NewNumber(1),
+						models.NewNumber(3),
+						models.NewNumber(2),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(3),
+			wantErr:    "",
+		},
+		{
+			name: "mode/few",
+			args: args{
+				name: "mode",
+				arguments: []models.Value{
+					models.NewNumber(3),
+					models.NewNumber(1),
+					models.NewNumber(3),
+					models.NewNumber(1),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(1),
+			wantErr:    "",
+		},
+		{
+			name: "variance/success",
+			args: args{
+				name: "variance",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewNumber(4),
+					models.NewNumber(4),
+					models.NewNumber(4),
+					models.NewNumber(5),
+					models.NewNumber(5),
+					models.NewNumber(7),
+					models.NewNumber(9),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(32.0 / 7),
+			wantErr:    "",
+		},
+		{
+			name: "variance/error",
+			args: args{
+				name: "variance",
+				arguments: []models.Value{
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "1 numbers are given, but at least 2 are expected",
+		},
+		{
+			name: "pvariance",
+			args: args{
+				name: "pvariance",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewNumber(4),
+					models.NewNumber(4),
+					models.NewNumber(4),
+					models.NewNumber(5),
+					models.NewNumber(5),
+					models.NewNumber(7),
+					models.NewNumber(9),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(4),
+			wantErr:    "",
+		},
+		{
+			name: "stddev",
+			args: args{
+				name: "stddev",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(2),
+						models.NewNumber(4),
+						models.NewNumber(4),
+						models.NewNumber(4),
+						models.NewNumber(5),
+						models.NewNumber(5),
+						models.NewNumber(7),
+						models.NewNumber(9),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(math.Sqrt(32.0 / 7)),
+			wantErr:    "",
+		},
+		{
+			name: "pstddev",
+			args: args{
+				name: "pstddev",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(2),
+						models.NewNumber(4),
+						models.NewNumber(4),
+						models.NewNumber(4),
+						models.NewNumber(5),
+						models.NewNumber(5),
+						models.NewNumber(7),
+						models.NewNumber(9),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "percentile/success",
+			args: args{
+				name: "percentile",
+				arguments: []models.Value{
+					models.NewNumber(25),
+					models.NewArray(
+						models.NewNumber(4),
+						models.NewNumber(1),
+						models.NewNumber(3),
+						models.NewNumber(2),
+					),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(1.75),
+			wantErr:    "",
+		},
+		{
+			name: "percentile/maximum",
+			args: args{
+				name: "percentile",
+				arguments: []models.Value{
+					models.NewNumber(100),
+					models.NewNumber(4),
+					models.NewNumber(1),
+					models.NewNumber(3),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(4),
+			wantErr:    "",
+		},
+		{
+			name: "percentile/error with the rank",
+			args: args{
+				name: "percentile",
+				arguments: []models.Value{
+					models.NewNumber(101),
+					models.NewNumber(1),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 should be in the range [0, 100]",
+		},
+		{
+			name: "percentile/error with the argument kind",
+			args: args{
+				name: "percentile",
+				arguments: []models.Value{
+					models.NewNumber(50),
+					models.NewString("test"),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "argument #1 has type string, but number is expected",
+		},
+		{
+			name: "min",
+			args: args{
+				name: "min",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewArray(
+						models.NewNumber(4),
+						models.NewNumber(1),
+						models.NewNumber(3),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(1),
+			wantErr:    "",
+		},
+		{
+			name: "max",
+			args: args{
+				name: "max",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewArray(
+						models.NewNumber(4),
+						models.NewNumber(1),
+						models.NewNumber(3),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(4),
+			wantErr:    "",
+		},
+
+		// vectors and matrices
+		{
+			name: "dot/success with vectors",
+			args: args{
+				name: "dot",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(1),
+						models.NewNumber(2),
+						models.NewNumber(3),
+					),
+					models.NewArray(
+						models.NewNumber(4),
+						models.NewNumber(5),
+						models.NewNumber(6),
+					),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(32),
+			wantErr:    "",
+		},
+		{
+			name: "dot/success with matrices",
+			args: args{
+				name: "dot",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(
+							models.NewNumber(3),
+							models.NewNumber(4),
+						),
+					),
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(5),
+							models.NewNumber(6),
+						),
+						models.NewArray(
+							models.NewNumber(7),
+							models.NewNumber(8),
+						),
+					),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewArray(
+				models.NewArray(models.NewNumber(19), models.NewNumber(22)),
+				models.NewArray(models.NewNumber(43), models.NewNumber(50)),
+			),
+			wantErr: "",
+		},
+		{
+			name: "dot/success with a matrix and a vector",
+			args: args{
+				name: "dot",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(
+							models.NewNumber(3),
+							models.NewNumber(4),
+						),
+					),
+					models.NewArray(models.NewNumber(5), models.NewNumber(6)),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewArray(
+				models.NewNumber(17),
+				models.NewNumber(39),
+			),
+			wantErr: "",
+		},
+		{
+			name: "dot/success with a vector and a matrix",
+			args: args{
+				name: "dot",
+				arguments: []models.Value{
+					models.NewArray(models.NewNumber(5), models.NewNumber(6)),
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(
+							models.NewNumber(3),
+							models.NewNumber(4),
+						),
+					),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewArray(
+				models.NewNumber(23),
+				models.NewNumber(34),
+			),
+			wantErr: "",
+		},
+		{
+			name: "dot/error with vector lengths",
+			args: args{
+				name: "dot",
+				arguments: []models.Value{
+					models.NewArray(models.NewNumber(1), models.NewNumber(2)),
+					models.NewArray(models.NewNumber(3)),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "vector lengths are different: 2 and 1",
+		},
+		{
+			name: "dot/error with matrix sizes",
+			args: args{
+				name: "dot",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(
+							models.NewNumber(3),
+							models.NewNumber(4),
+						),
+					),
+					models.NewArray(
+						models.NewNumber(5),
+						models.NewNumber(6),
+						models.NewNumber(7),
+					),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "matrix sizes are incompatible: 2x2 and 3x1",
+		},
+		{
+			name: "cross/success",
+			args: args{
+				name: "cross",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(1),
+						models.NewNumber(2),
+						models.NewNumber(3),
+					),
+					models.NewArray(
+						models.NewNumber(4),
+						models.NewNumber(5),
+						models.NewNumber(6),
+					),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewArray(
+				models.NewNumber(-3),
+				models.NewNumber(6),
+				models.NewNumber(-3),
+			),
+			wantErr: "",
+		},
+		{
+			name: "cross/error",
+			args: args{
+				name: "cross",
+				arguments: []models.Value{
+					models.NewArray(models.NewNumber(1), models.NewNumber(2)),
+					models.NewArray(
+						models.NewNumber(4),
+						models.NewNumber(5),
+						models.NewNumber(6),
+					),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 has length 2, but 3 is expected",
+		},
+		{
+			name: "norm/success with a vector",
+			args: args{
+				name: "norm",
+				arguments: []models.Value{
+					models.NewArray(models.NewNumber(3), models.NewNumber(4)),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(5),
+			wantErr:    "",
+		},
+		{
+			name: "norm/success with a matrix",
+			args: args{
+				name: "norm",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(1),
+						),
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(1),
+						),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "norm/error",
+			args: args{
+				name: "norm",
+				arguments: []models.Value{
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "value of type number isn't a vector",
+		},
+		{
+			name: "transpose/success",
+			args: args{
+				name: "transpose",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+							models.NewNumber(3),
+						),
+						models.NewArray(
+							models.NewNumber(4),
+							models.NewNumber(5),
+							models.NewNumber(6),
+						),
+					),
+				},
+			},
+			wantArity: 1,
+			wantResult: models.NewArray(
+				models.NewArray(models.NewNumber(1), models.NewNumber(4)),
+				models.NewArray(models.NewNumber(2), models.NewNumber(5)),
+				models.NewArray(models.NewNumber(3), models.NewNumber(6)),
+			),
+			wantErr: "",
+		},
+		{
+			name: "transpose/error",
+			args: args{
+				name: "transpose",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(models.NewNumber(3)),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "row #1 has an incorrect length",
+		},
+		{
+			name: "det/success",
+			args: args{
+				name: "det",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(1),
+						),
+						models.NewArray(
+							models.NewNumber(0),
+							models.NewNumber(4),
+						),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(8),
+			wantErr:    "",
+		},
+		{
+			name: "det/error",
+			args: args{
+				name: "det",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+							models.NewNumber(3),
+						),
+						models.NewArray(
+							models.NewNumber(4),
+							models.NewNumber(5),
+							models.NewNumber(6),
+						),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "matrix 2x3 isn't square",
+		},
+		{
+			name: "inv/success",
+			args: args{
+				name: "inv",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(0),
+						),
+						models.NewArray(
+							models.NewNumber(0),
+							models.NewNumber(4),
+						),
+					),
+				},
+			},
+			wantArity: 1,
+			wantResult: models.NewArray(
+				models.NewArray(models.NewNumber(0.5), models.NewNumber(0)),
+				models.NewArray(models.NewNumber(0), models.NewNumber(0.25)),
+			),
+			wantErr: "",
+		},
+		{
+			name: "inv/error",
+			args: args{
+				name: "inv",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(4),
+						),
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "matrix is singular",
+		},
+		{
+			name: "solve/success with a vector",
+			args: args{
+				name: "solve",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(0),
+						),
+						models.NewArray(
+							models.NewNumber(0),
+							models.NewNumber(4),
+						),
+					),
+					models.NewArray(models.NewNumber(2), models.NewNumber(8)),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewArray(
+				models.NewNumber(1),
+				models.NewNumber(2),
+			),
+			wantErr: "",
+		},
+		{
+			name: "solve/success with a matrix",
+			args: args{
+				name: "solve",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(0),
+						),
+						models.NewArray(
+							models.NewNumber(0),
+							models.NewNumber(4),
+						),
+					),
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(4),
+						),
+						models.NewArray(
+							models.NewNumber(8),
+							models.NewNumber(4),
+						),
+					),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewArray(
+				models.NewArray(models.NewNumber(1), models.NewNumber(2)),
+				models.NewArray(models.NewNumber(2), models.NewNumber(1)),
+			),
+			wantErr: "",
+		},
+		{
+			name: "solve/error",
+			args: args{
+				name: "solve",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewArray(
+							models.NewNumber(1),
+							models.NewNumber(2),
+						),
+						models.NewArray(
+							models.NewNumber(2),
+							models.NewNumber(4),
+						),
+					),
+					models.NewArray(models.NewNumber(1), models.NewNumber(2)),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "matrix is singular",
+		},
+
+		// strings
+		{
+			name: "concat/success",
+			args: args{
+				name: "concat",
+				arguments: []models.Value{
+					models.NewString("one"),
+					models.NewString("two"),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewString("onetwo"),
+			wantErr:    "",
+		},
+		{
+			name: "concat/error",
+			args: args{
+				name: "concat",
+				arguments: []models.Value{
+					models.NewString("one"),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "argument #1 has type number, but string is expected",
+		},
+		{
+			name: "len/success",
+			args: args{
+				name:      "len",
+				arguments: []models.Value{models.NewString("тест")},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(4),
+			wantErr:    "",
+		},
+		{
+			name: "len/error",
+			args: args{
+				name:      "len",
+				arguments: []models.Value{models.NewNumber(2)},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 has type number, but string is expected",
+		},
+		{
+			name: "str/success",
+			args: args{
+				name:      "str",
+				arguments: []models.Value{models.NewNumber(2.5)},
+			},
+			wantArity:  1,
+			wantResult: models.NewString("2.5"),
+			wantErr:    "",
+		},
+		{
+			name: "str/error",
+			args: args{
+				name:      "str",
+				arguments: []models.Value{models.NewString("test")},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 has type string, but number is expected",
+		},
+		{
+			name: "num/success",
+			args: args{
+				name:      "num",
+				arguments: []models.Value{models.NewString(" 2.5e3 ")},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(2500),
+			wantErr:    "",
+		},
+		{
+			name: "num/error with a type",
+			args: args{
+				name:      "num",
+				arguments: []models.Value{models.NewNumber(2)},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 has type number, but string is expected",
+		},
+		{
+			name: "num/error with parsing",
+			args: args{
+				name:      "num",
+				arguments: []models.Value{models.NewString("test")},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr: "unable to parse the number: " +
+				"strconv.ParseFloat: parsing \"test\": invalid syntax",
+		},
+
+		// quantities
+		{
+			name: "+/success with quantities",
+			args: args{
+				name: "+",
+				arguments: []models.Value{
+					models.NewQuantity(2, meter),
+					models.NewQuantity(3, meter),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewQuantity(5, meter),
+			wantErr:    "",
+		},
+		{
+			name: "-/success with zero",
+			args: args{
+				name: "-",
+				arguments: []models.Value{
+					models.NewNumber(0),
+					models.NewQuantity(3, meter),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewQuantity(-3, meter),
+			wantErr:    "",
+		},
+		{
+			name: "+/error with dimensions",
+			args: args{
+				name: "+",
+				arguments: []models.Value{
+					models.NewQuantity(2, meter),
+					models.NewQuantity(3, second),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "dimensions are different: m and s",
+		},
+		{
+			name: "//success with a dimensionless result",
+			args: args{
+				name: "/",
+				arguments: []models.Value{
+					models.NewQuantity(6, meter),
+					models.NewQuantity(3, meter),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "*/success",
+			args: args{
+				name: "*",
+				arguments: []models.Value{
+					models.NewQuantity(2, meter),
+					models.NewQuantity(3, second),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewQuantity(6, meter.Multiply(second)),
+			wantErr:    "",
+		},
+		{
+			name: "^/success",
+			args: args{
+				name: "^",
+				arguments: []models.Value{
+					models.NewQuantity(2, meter),
+					models.NewNumber(3),
+				},
+			},
+			wantArity: 2,
+			wantResult: models.NewQuantity(
+				8,
+				models.Dimension{models.LengthDimension: 3},
+			),
+			wantErr: "",
+		},
+		{
+			name: "^/error",
+			args: args{
+				name: "^",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewQuantity(3, second),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "exponent has dimension s, but it should be dimensionless",
+		},
+		{
+			name: "sqrt/success",
+			args: args{
+				name: "sqrt",
+				arguments: []models.Value{
+					models.NewQuantity(
+						16,
+						models.Dimension{models.LengthDimension: 2},
+					),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewQuantity(4, meter),
+			wantErr:    "",
+		},
+		{
+			name: "sqrt/error",
+			args: args{
+				name:      "sqrt",
+				arguments: []models.Value{models.NewQuantity(16, meter)},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "unable to raise the dimension m to the power 0.5",
+		},
+		{
+			name: "sin/error",
+			args: args{
+				name:      "sin",
+				arguments: []models.Value{models.NewQuantity(2, meter)},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #0 has type quantity, but number is expected",
+		},
+
+		// type errors
+		{
+			name: "+/error with a string",
+			args: args{
+				name: "+",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewString("test"),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "argument #1 has type string, but number is expected",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotFunction, gotOk := BuiltInFunctions[testCase.args.name]
+			require.True(test, gotOk)
+
+			gotResult, gotErr := gotFunction.Call(testCase.args.arguments)
+
+			assert.Equal(test, testCase.wantArity, gotFunction.Arity)
+			assert.Equal(test, testCase.wantResult, gotResult)
 			if testCase.wantErr == "" {
 				assert.NoError(test, gotErr)
 			} else {
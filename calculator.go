@@ -1,7 +1,7 @@
 package calculator
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/irenicaa/go-calculator/v2/evaluator"
 	"github.com/irenicaa/go-calculator/v2/models"
@@ -9,11 +9,17 @@ import (
 	"github.com/irenicaa/go-calculator/v2/translator"
 )
 
-// Calculator ...
+// Calculator calculates code that may be passed in several parts.
+//
+// It isn't safe for concurrent use, so use a calculator per goroutine.
+// It reads the variables and the functions without copying them,
+// so they shouldn't be changed until the calculator is finalized.
 type Calculator struct {
-	variables      models.VariableGroup
-	functions      models.FunctionGroup
-	functionsNames models.FunctionNameGroup
+	variables models.VariableGroup
+	functions models.FunctionProvider
+	options   options
+	// the interpreter replaces it to stop the evaluation
+	ctx context.Context
 
 	tokenizer  tokenizer.Tokenizer
 	translator translator.Translator
@@ -23,12 +29,19 @@ type Calculator struct {
 // NewCalculator ...
 func NewCalculator(
 	variables models.VariableGroup,
-	functions models.FunctionGroup,
+	functions models.FunctionProvider,
+	options ...Option,
 ) *Calculator {
+	calculatorOptions := newOptions(options)
+	if len(calculatorOptions.constants) != 0 {
+		variables = variables.Merge(calculatorOptions.constants.Variables())
+	}
+
 	return &Calculator{
-		variables:      variables,
-		functions:      functions,
-		functionsNames: functions.Names(),
+		variables: variables,
+		functions: functions,
+		options:   calculatorOptions,
+		ctx:       context.Background(),
 	}
 }
 
@@ -36,61 +49,109 @@ func NewCalculator(
 func (calculator *Calculator) Calculate(code string) error {
 	tokens, err := calculator.tokenizer.Tokenize(code)
 	if err != nil {
-		return fmt.Errorf("unable to tokenize the code: %s", err)
+		return &Error{
+			Stage:   TokenizationStage,
+			Message: "unable to tokenize the code",
+			Err:     err,
+		}
 	}
 
 	commands, err := calculator.translator.Translate(
 		tokens,
-		calculator.functionsNames,
+		calculator.functions,
 	)
 	if err != nil {
-		return fmt.Errorf("unable to translate the tokens: %s", err)
+		return &Error{
+			Stage:   TranslationStage,
+			Message: "unable to translate the tokens",
+			Err:     err,
+		}
+	}
+	if err := calculator.options.checkCommands(commands); err != nil {
+		return err
 	}
 
-	err = calculator.evaluator.Evaluate(
+	err = calculator.evaluator.EvaluateContext(
+		calculator.ctx,
 		commands,
 		calculator.variables,
 		calculator.functions,
 	)
 	if err != nil {
-		return fmt.Errorf("unable to evaluate the commands: %s", err)
+		return &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to evaluate the commands",
+			Err:     err,
+		}
 	}
 
 	return nil
 }
 
 // Finalize ...
-func (calculator *Calculator) Finalize() (float64, error) {
+func (calculator *Calculator) Finalize() (models.Value, error) {
 	tokens, err := calculator.tokenizer.Finalize()
 	if err != nil {
-		return 0, fmt.Errorf("unable to finalize the tokenizer: %s", err)
+		return models.Value{}, &Error{
+			Stage:   TokenizationStage,
+			Message: "unable to finalize the tokenizer",
+			Err:     err,
+		}
 	}
 
-	// data that came from that Finalize() call
-	// can't lead to an error inside the Translate() call
-	commands, _ := calculator.translator.Translate(
+	return calculator.finalizeTokens(tokens)
+}
+
+func (calculator *Calculator) finalizeTokens(
+	tokens []models.Token,
+) (models.Value, error) {
+	commands, err := calculator.translator.Translate(
 		tokens,
-		calculator.functionsNames,
+		calculator.functions,
 	)
+	if err != nil {
+		return models.Value{}, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to translate the tokens",
+			Err:     err,
+		}
+	}
+
 	additionalCommands, err := calculator.translator.Finalize()
 	if err != nil {
-		return 0, fmt.Errorf("unable to finalize the translator: %s", err)
+		return models.Value{}, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to finalize the translator",
+			Err:     err,
+		}
 	}
 	commands = append(commands, additionalCommands...)
+	if err := calculator.options.checkCommands(commands); err != nil {
+		return models.Value{}, err
+	}
 
-	err = calculator.evaluator.Evaluate(
+	err = calculator.evaluator.EvaluateContext(
+		calculator.ctx,
 		commands,
 		calculator.variables,
 		calculator.functions,
 	)
 	if err != nil {
-		return 0, fmt.Errorf("unable to evaluate the commands: %s", err)
+		return models.Value{}, &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to evaluate the commands",
+			Err:     err,
+		}
 	}
 
-	number, err := calculator.evaluator.Finalize()
+	value, err := calculator.evaluator.Finalize()
 	if err != nil {
-		return 0, fmt.Errorf("unable to finalize the evaluator: %s", err)
+		return models.Value{}, &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to finalize the evaluator",
+			Err:     err,
+		}
 	}
 
-	return number, nil
+	return value, nil
 }
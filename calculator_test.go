@@ -18,11 +18,11 @@ func TestCalculator(test *testing.T) {
 	}
 
 	testsCases := []struct {
-		name       string
-		fields     fields
-		args       args
-		wantNumber float64
-		wantErr    string
+		name      string
+		fields    fields
+		args      args
+		wantValue models.Value
+		wantErr   string
 	}{
 		{
 			name: "success with numbers",
@@ -37,14 +37,14 @@ func TestCalculator(test *testing.T) {
 					},
 				},
 			},
-			args:       args{code: "2 + 3"},
-			wantNumber: 5,
-			wantErr:    "",
+			args:      args{code: "2 + 3"},
+			wantValue: models.NewNumber(5),
+			wantErr:   "",
 		},
 		{
 			name: "success with variables",
 			fields: fields{
-				variables: models.VariableGroup{"x": 2, "y": 3},
+				variables: models.VariableGroup{"x": models.NewNumber(2), "y": models.NewNumber(3)},
 				functions: models.FunctionGroup{
 					"+": {
 						Arity: 2,
@@ -54,9 +54,9 @@ func TestCalculator(test *testing.T) {
 					},
 				},
 			},
-			args:       args{code: "x + y"},
-			wantNumber: 5,
-			wantErr:    "",
+			args:      args{code: "x + y"},
+			wantValue: models.NewNumber(5),
+			wantErr:   "",
 		},
 		{
 			name: "success with function calls",
@@ -84,9 +84,32 @@ func TestCalculator(test *testing.T) {
 					},
 				},
 			},
-			args:       args{code: "floor(2.3) + ceil(2.3)"},
-			wantNumber: 5,
-			wantErr:    "",
+			args:      args{code: "floor(2.3) + ceil(2.3)"},
+			wantValue: models.NewNumber(5),
+			wantErr:   "",
+		},
+		{
+			name: "success with optional arguments",
+			fields: fields{
+				variables: nil,
+				functions: BuiltInFunctions,
+			},
+			args:      args{code: "log(8, 2) + log(1)"},
+			wantValue: models.NewNumber(3),
+			wantErr:   "",
+		},
+		{
+			name: "success with variadic functions",
+			fields: fields{
+				variables: nil,
+				functions: BuiltInFunctions,
+			},
+			args: args{code: "mean(1, 2, [3, 4]) * max(2) * 20 min"},
+			wantValue: models.NewQuantity(
+				6000,
+				models.NewBaseDimension(models.TimeDimension),
+			),
+			wantErr: "",
 		},
 
 		// errors
@@ -96,9 +119,9 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: "2 @ 3"},
-			wantNumber: 0,
-			wantErr:    "unable to tokenize the code: unknown symbol '@' at position 2",
+			args:      args{code: "2 @ 3"},
+			wantValue: models.Value{},
+			wantErr:   "unable to tokenize the code: unknown symbol '@' at position 2",
 		},
 		{
 			name: "error with translation",
@@ -106,8 +129,8 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: "2 + 3)"},
-			wantNumber: 0,
+			args:      args{code: "2 + 3)"},
+			wantValue: models.Value{},
 			wantErr: "unable to translate the tokens: " +
 				"missed pair for token {Kind:9 Value:)} with number #3",
 		},
@@ -117,10 +140,10 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: "x + 3"},
-			wantNumber: 0,
+			args:      args{code: "x + 3"},
+			wantValue: models.Value{},
 			wantErr: "unable to evaluate the commands: " +
-				"unknown variable in command {Kind:1 Operand:x} with number #0",
+				"unknown variable in command {Kind:1 Operand:x ArgumentCount:0} with number #0",
 		},
 		{
 			name: "error with finalizing of tokenization",
@@ -128,8 +151,8 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: "2 + ."},
-			wantNumber: 0,
+			args:      args{code: "2 + ."},
+			wantValue: models.Value{},
 			wantErr: "unable to finalize the tokenizer: " +
 				"both integer and fractional parts are empty at EOI",
 		},
@@ -139,8 +162,8 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: "(2 + 3"},
-			wantNumber: 0,
+			args:      args{code: "(2 + 3"},
+			wantValue: models.Value{},
 			wantErr: "unable to finalize the translator: " +
 				"missed pair for token {Kind:8 Value:(}",
 		},
@@ -150,10 +173,10 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: "2 + x"},
-			wantNumber: 0,
+			args:      args{code: "2 + x"},
+			wantValue: models.Value{},
 			wantErr: "unable to evaluate the commands: " +
-				"unknown variable in command {Kind:1 Operand:x} with number #0",
+				"unknown variable in command {Kind:1 Operand:x ArgumentCount:0} with number #0",
 		},
 		{
 			name: "error with finalizing of evaluation",
@@ -161,14 +184,14 @@ func TestCalculator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			args:       args{code: ""},
-			wantNumber: 0,
-			wantErr:    "unable to finalize the evaluator: number stack is empty",
+			args:      args{code: ""},
+			wantValue: models.Value{},
+			wantErr:   "unable to finalize the evaluator: value stack is empty",
 		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
-			gotNumber := 0.0
+			gotValue := models.Value{}
 
 			calculator := NewCalculator(
 				testCase.fields.variables,
@@ -176,10 +199,10 @@ func TestCalculator(test *testing.T) {
 			)
 			gotErr := calculator.Calculate(testCase.args.code)
 			if gotErr == nil {
-				gotNumber, gotErr = calculator.Finalize()
+				gotValue, gotErr = calculator.Finalize()
 			}
 
-			assert.Equal(test, testCase.wantNumber, gotNumber)
+			assert.Equal(test, testCase.wantValue, gotValue)
 			if testCase.wantErr == "" {
 				assert.NoError(test, gotErr)
 			} else {
@@ -199,15 +222,15 @@ func TestCalculator_withSequentialCalls(test *testing.T) {
 	}
 
 	testsCases := []struct {
-		name       string
-		fields     fields
-		args       args
-		wantNumber float64
+		name      string
+		fields    fields
+		args      args
+		wantValue models.Value
 	}{
 		{
 			name: "success",
 			fields: fields{
-				variables: models.VariableGroup{"number2": 2, "number3": 3},
+				variables: models.VariableGroup{"number2": models.NewNumber(2), "number3": models.NewNumber(3)},
 				functions: models.FunctionGroup{
 					"+": {
 						Arity: 2,
@@ -217,13 +240,13 @@ func TestCalculator_withSequentialCalls(test *testing.T) {
 					},
 				},
 			},
-			args:       args{codeParts: []string{"(number", "2", "+", "number", "3)"}},
-			wantNumber: 5,
+			args:      args{codeParts: []string{"(number", "2", "+", "number", "3)"}},
+			wantValue: models.NewNumber(5),
 		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
-			gotNumber, gotErr := 0.0, error(nil)
+			gotValue, gotErr := models.Value{}, error(nil)
 
 			calculator := NewCalculator(
 				testCase.fields.variables,
@@ -236,10 +259,10 @@ func TestCalculator_withSequentialCalls(test *testing.T) {
 				}
 			}
 			if gotErr == nil {
-				gotNumber, gotErr = calculator.Finalize()
+				gotValue, gotErr = calculator.Finalize()
 			}
 
-			assert.Equal(test, testCase.wantNumber, gotNumber)
+			assert.Equal(test, testCase.wantValue, gotValue)
 			assert.NoError(test, gotErr)
 		})
 	}
@@ -0,0 +1,283 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/symbolic"
+)
+
+// parameters of the numerical differentiation and integration;
+// the step and the tolerance are relative for large arguments and results
+const (
+	differentiationStep       = 1e-3
+	integrationTolerance      = 1e-10
+	maxIntegrationDepth       = 50
+	maxIntegrationEvaluations = 1e5
+	maxSeriesTermCount        = 1e6
+)
+
+// deriv(expression, variable, x)
+func differentiate(arguments []models.Value) (models.Value, error) {
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	x := arguments[2].Number
+	step := differentiationStep * math.Max(1, math.Abs(x))
+	calculateDifference := func(step float64) (float64, error) {
+		nextValue, err := function.evaluate(x + step)
+		if err != nil {
+			return 0, err
+		}
+
+		previousValue, err := function.evaluate(x - step)
+		if err != nil {
+			return 0, err
+		}
+
+		return (nextValue - previousValue) / (2 * step), nil
+	}
+
+	roughDerivative, err := calculateDifference(step)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	fineDerivative, err := calculateDifference(step / 2)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	// the Richardson extrapolation cancels the error term of the second order
+	return models.NewNumber((4*fineDerivative - roughDerivative) / 3), nil
+}
+
+// diff(expression, variable) returns the derivative as code
+func differentiateSymbolically(arguments []models.Value) (models.Value, error) {
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	node, err := symbolic.Parse(function.expression.Commands)
+	if err != nil {
+		return models.Value{}, fmt.Errorf("unable to parse the expression: %w", err)
+	}
+
+	derivative, err := symbolic.Differentiate(node, function.variable)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewString(derivative.String()), nil
+}
+
+// integrate(expression, variable, a, b)
+func integrate(arguments []models.Value) (models.Value, error) {
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	finiteFunction := func(x float64) (float64, error) {
+		value, err := function.evaluate(x)
+		if err != nil {
+			return 0, err
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return 0, fmt.Errorf(
+				"expression isn't finite for %s = %g",
+				function.variable,
+				x,
+			)
+		}
+
+		return value, nil
+	}
+
+	integral, err := integrateSimpson(
+		finiteFunction,
+		arguments[2].Number,
+		arguments[3].Number,
+	)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(integral), nil
+}
+
+// sum(expression, variable, from, to)
+func sumSeries(arguments []models.Value) (models.Value, error) {
+	result := 0.0
+	err := iterateSeries(arguments, func(term float64) { result += term })
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(result), nil
+}
+
+// prod(expression, variable, from, to)
+func multiplySeries(arguments []models.Value) (models.Value, error) {
+	result := 1.0
+	err := iterateSeries(arguments, func(term float64) { result *= term })
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(result), nil
+}
+
+// it evaluates the expression for integers in the range [from, to];
+// the range is empty if from is greater than to
+func iterateSeries(
+	arguments []models.Value,
+	handler func(term float64),
+) error {
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return err
+	}
+
+	from, to := arguments[2].Number, arguments[3].Number
+	for argumentIndex, bound := range []float64{from, to} {
+		if bound != math.Trunc(bound) {
+			return fmt.Errorf("argument #%d isn't an integer", argumentIndex+2)
+		}
+	}
+
+	if to-from >= maxSeriesTermCount {
+		return errors.New("range is too large")
+	}
+
+	for index := from; index <= to; index++ {
+		term, err := function.evaluate(index)
+		if err != nil {
+			return err
+		}
+
+		handler(term)
+	}
+
+	return nil
+}
+
+// it integrates the function by the adaptive Simpson's method
+func integrateSimpson(
+	function func(x float64) (float64, error),
+	lower float64,
+	upper float64,
+) (float64, error) {
+	lowerValue, err := function(lower)
+	if err != nil {
+		return 0, err
+	}
+
+	upperValue, err := function(upper)
+	if err != nil {
+		return 0, err
+	}
+
+	middle := lower + (upper-lower)/2
+	middleValue, err := function(middle)
+	if err != nil {
+		return 0, err
+	}
+
+	integrator := &simpsonIntegrator{function: function}
+	return integrator.integrate(
+		simpsonSegment{
+			lower:       lower,
+			upper:       upper,
+			lowerValue:  lowerValue,
+			middleValue: middleValue,
+			upperValue:  upperValue,
+		},
+		integrationTolerance*math.Max(1, math.Abs(upper-lower)),
+		maxIntegrationDepth,
+	)
+}
+
+type simpsonIntegrator struct {
+	function        func(x float64) (float64, error)
+	evaluationCount int
+}
+
+type simpsonSegment struct {
+	lower       float64
+	upper       float64
+	lowerValue  float64
+	middleValue float64
+	upperValue  float64
+}
+
+func (segment simpsonSegment) middle() float64 {
+	return segment.lower + (segment.upper-segment.lower)/2
+}
+
+func (segment simpsonSegment) estimate() float64 {
+	return (segment.upper - segment.lower) / 6 *
+		(segment.lowerValue + 4*segment.middleValue + segment.upperValue)
+}
+
+// it splits the segment in halves until the estimate of the whole segment
+// is close to the sum of estimates of the halves
+func (integrator *simpsonIntegrator) integrate(
+	segment simpsonSegment,
+	tolerance float64,
+	depth int,
+) (float64, error) {
+	integrator.evaluationCount += 2
+	if integrator.evaluationCount > maxIntegrationEvaluations {
+		return 0, errors.New("integral didn't converge")
+	}
+
+	middle := segment.middle()
+	leftMiddleValue, err := integrator.function(segment.lower + (middle-segment.lower)/2)
+	if err != nil {
+		return 0, err
+	}
+
+	rightMiddleValue, err := integrator.function(middle + (segment.upper-middle)/2)
+	if err != nil {
+		return 0, err
+	}
+
+	left := simpsonSegment{
+		lower:       segment.lower,
+		upper:       middle,
+		lowerValue:  segment.lowerValue,
+		middleValue: leftMiddleValue,
+		upperValue:  segment.middleValue,
+	}
+	right := simpsonSegment{
+		lower:       middle,
+		upper:       segment.upper,
+		lowerValue:  segment.middleValue,
+		middleValue: rightMiddleValue,
+		upperValue:  segment.upperValue,
+	}
+
+	difference := left.estimate() + right.estimate() - segment.estimate()
+	if depth == 0 || math.Abs(difference) <= 15*tolerance {
+		// the correction term of the Richardson extrapolation
+		return left.estimate() + right.estimate() + difference/15, nil
+	}
+
+	leftIntegral, err := integrator.integrate(left, tolerance/2, depth-1)
+	if err != nil {
+		return 0, err
+	}
+
+	rightIntegral, err := integrator.integrate(right, tolerance/2, depth-1)
+	if err != nil {
+		return 0, err
+	}
+
+	return leftIntegral + rightIntegral, nil
+}
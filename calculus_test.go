@@ -0,0 +1,229 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculusFunctions(test *testing.T) {
+	type args struct {
+		variables models.VariableGroup
+		code      string
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantResult float64
+		wantErr    string
+	}{
+		{
+			name: "deriv/success",
+			args: args{
+				variables: nil,
+				code:      "deriv(x^3, x, 2)",
+			},
+			wantResult: 12,
+			wantErr:    "",
+		},
+		{
+			name: "deriv/success with a large argument",
+			args: args{
+				variables: nil,
+				code:      "deriv(log(x), x, 1000)",
+			},
+			wantResult: 0.001,
+			wantErr:    "",
+		},
+		{
+			name: "deriv/error",
+			args: args{
+				variables: nil,
+				code:      "deriv(log(x), x, 0)",
+			},
+			wantResult: 0,
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:deriv ArgumentCount:3} with number #8: " +
+				"unable to evaluate the expression for x = -0.001: " +
+				"unable to call the function from command " +
+				"{Kind:2 Operand:log ArgumentCount:1} with number #1: " +
+				"argument #0 should be at least 0",
+		},
+		{
+			name: "integrate/success",
+			args: args{
+				variables: nil,
+				code:      "integrate(4 / (1 + x^2), x, 0, 1)",
+			},
+			wantResult: 3.141592654,
+			wantErr:    "",
+		},
+		{
+			name: "integrate/success with a singular derivative",
+			args: args{
+				variables: nil,
+				code:      "integrate(sqrt(x), x, 0, 1)",
+			},
+			wantResult: 0.666666667,
+			wantErr:    "",
+		},
+		{
+			name: "integrate/success with reversed bounds",
+			args: args{
+				variables: nil,
+				code:      "integrate(x, x, 2, 0)",
+			},
+			wantResult: -2,
+			wantErr:    "",
+		},
+		{
+			name: "integrate/error with an infinite value",
+			args: args{
+				variables: nil,
+				code:      "integrate(1 / x, x, 0, 1)",
+			},
+			wantResult: 0,
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:integrate ArgumentCount:4} with number #10: " +
+				"expression isn't finite for x = 0",
+		},
+		{
+			name: "integrate/error with the convergence",
+			args: args{
+				variables: nil,
+				code:      "integrate(rand(), x, 0, 1)",
+			},
+			wantResult: 0,
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:integrate ArgumentCount:4} with number #8: " +
+				"integral didn't converge",
+		},
+		{
+			name: "sum/success",
+			args: args{
+				variables: nil,
+				code:      "sum(1 / 2^k, k, 0, 50)",
+			},
+			wantResult: 2,
+			wantErr:    "",
+		},
+		{
+			name: "sum/success with an empty range",
+			args: args{
+				variables: nil,
+				code:      "sum(k, k, 5, 1)",
+			},
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "sum/success with a shadowed variable",
+			args: args{
+				variables: models.VariableGroup{"k": models.NewNumber(100)},
+				code:      "sum(k, k, 1, 3) + k",
+			},
+			wantResult: 106,
+			wantErr:    "",
+		},
+		{
+			name: "sum/error with a fraction",
+			args: args{
+				variables: nil,
+				code:      "sum(k, k, 1, 2.5)",
+			},
+			wantResult: 0,
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:sum ArgumentCount:4} with number #8: " +
+				"argument #3 isn't an integer",
+		},
+		{
+			name: "sum/error with the range",
+			args: args{
+				variables: nil,
+				code:      "sum(k, k, 1, 1e7)",
+			},
+			wantResult: 0,
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:sum ArgumentCount:4} with number #8: " +
+				"range is too large",
+		},
+		{
+			name: "prod/success",
+			args: args{
+				variables: nil,
+				code:      "prod(k, k, 1, 5)",
+			},
+			wantResult: 120,
+			wantErr:    "",
+		},
+		{
+			name: "prod/success with an empty range",
+			args: args{
+				variables: nil,
+				code:      "prod(k, k, 1, 0)",
+			},
+			wantResult: 1,
+			wantErr:    "",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotResult, gotErr := models.Value{}, error(nil)
+
+			calculator := NewCalculator(testCase.args.variables, BuiltInFunctions)
+			if gotErr = calculator.Calculate(testCase.args.code); gotErr == nil {
+				gotResult, gotErr = calculator.Finalize()
+			}
+
+			assert.InDelta(test, testCase.wantResult, gotResult.Number, 1e-6)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestDifferentiateSymbolically(test *testing.T) {
+	calculate := func(variables models.VariableGroup, code string) models.Value {
+		calculator := NewCalculator(variables, BuiltInFunctions)
+		require.NoError(test, calculator.Calculate(code))
+
+		value, err := calculator.Finalize()
+		require.NoError(test, err)
+
+		return value
+	}
+
+	for _, code := range []string{
+		"3 x^4 - 2 x + 1",
+		"x^3 / (1 + x^2)",
+		"sin(x)^2 * cos(2 x)",
+		"exp(0 - x^2 / 2)",
+		"log(x^2 + 1, 2) + log10(x + 3)",
+		"atan2(x, 2) + atan(x) + asinh(x)",
+		"sqrt(x + 4) + cbrt(x + 5) + erf(x)",
+		"(x + 2)^x",
+		"tanh(x) * sinh(x) / cosh(x)",
+		"asin(x / 2) + acos(x / 3) + tan(x / 2)",
+		"acosh(x + 1) + atanh(x / 2) + erfc(x)",
+		"log2(x) * deg(x) + rad(x) * abs(x) + x % 0.7",
+	} {
+		test.Run(code, func(test *testing.T) {
+			derivative := calculate(nil, "diff("+code+", x)")
+			require.Equal(test, models.StringValue, derivative.Kind)
+
+			for _, x := range []float64{0.5, 1, 1.5} {
+				variables := models.VariableGroup{"x": models.NewNumber(x)}
+				got := calculate(variables, derivative.Text)
+				want := calculate(variables, "deriv("+code+", x, x)")
+
+				assert.InDelta(test, want.Number, got.Number, 1e-6, derivative.Text)
+			}
+		})
+	}
+}
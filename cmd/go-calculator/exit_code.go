@@ -0,0 +1,23 @@
+package main
+
+import "github.com/irenicaa/go-calculator/v2"
+
+const (
+	successExitCode = 0
+	// the flag package uses the code 2 for incorrect usage
+	usageErrorExitCode   = 2
+	syntaxErrorExitCode  = 3
+	runtimeErrorExitCode = 4
+	ioErrorExitCode      = 5
+)
+
+func exitCodeFromStage(stage string) int {
+	switch calculator.Stage(stage) {
+	case calculator.TokenizationStage, calculator.TranslationStage:
+		return syntaxErrorExitCode
+	case calculator.EvaluationStage:
+		return runtimeErrorExitCode
+	default:
+		return ioErrorExitCode
+	}
+}
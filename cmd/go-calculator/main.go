@@ -2,45 +2,289 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"unicode"
 
 	"github.com/irenicaa/go-calculator/v2"
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
 )
 
 func printError(err error) {
-	fmt.Printf("error: %s\n", err)
+	fmt.Fprintf(os.Stderr, "error: %s\n", err)
 }
 
 func main() {
+	outputFormat := flag.String(
+		"output",
+		"text",
+		"output format: text, json or jsonl (JSON Lines)",
+	)
+	failFast := flag.Bool(
+		"fail-fast",
+		false,
+		"stop at the first failed statement",
+	)
+	quiet := flag.Bool(
+		"quiet",
+		false,
+		"don't write results of assignments",
+	)
+	complexMode := flag.Bool(
+		"complex",
+		false,
+		"enable the complex number mode",
+	)
+	financeMode := flag.Bool(
+		"finance",
+		false,
+		"enable financial functions",
+	)
+	angleModeName := flag.String(
+		"angle",
+		"rad",
+		"angle mode of trigonometric functions: rad, deg or grad",
+	)
+	seed := flag.Int64(
+		"seed",
+		0,
+		"seed of random functions for reproducible results (default: random)",
+	)
+	moduleDirectory := flag.String(
+		"modules",
+		".",
+		"directory of modules of the import statement",
+	)
 	flag.Parse()
 
-	bufStdin := bufio.NewReader(os.Stdin)
+	hasSeed := false
+	flag.Visit(func(setFlag *flag.Flag) {
+		if setFlag.Name == "seed" {
+			hasSeed = true
+		}
+	})
+
+	resultWriter, err := newResultWriter(*outputFormat, os.Stdout, os.Stderr)
+	if err != nil {
+		printError(err)
+		os.Exit(usageErrorExitCode)
+	}
+
+	angleMode, err := calculator.ParseAngleMode(*angleModeName)
+	if err != nil {
+		printError(err)
+		os.Exit(usageErrorExitCode)
+	}
+
+	exitCode := run(os.Stdin, resultWriter, options{
+		failFast:        *failFast,
+		quiet:           *quiet,
+		complexMode:     *complexMode,
+		financeMode:     *financeMode,
+		angleMode:       angleMode,
+		seed:            *seed,
+		hasSeed:         hasSeed,
+		moduleDirectory: *moduleDirectory,
+	})
+	if err := resultWriter.Close(); err != nil {
+		printError(err)
+		if exitCode == successExitCode {
+			exitCode = ioErrorExitCode
+		}
+	}
+
+	os.Exit(exitCode)
+}
+
+type options struct {
+	failFast        bool
+	quiet           bool
+	complexMode     bool
+	financeMode     bool
+	angleMode       calculator.AngleMode
+	seed            int64
+	hasSeed         bool
+	moduleDirectory string
+}
+
+func run(reader io.Reader, resultWriter resultWriter, options options) int {
+	exitCode := successExitCode
+	handleResult := func(result result) (stop bool) {
+		if options.quiet && result.Variable != "" && result.Error == nil {
+			return false
+		}
+
+		if err := resultWriter.WriteResult(result); err != nil {
+			printError(err)
+			if exitCode == successExitCode {
+				exitCode = ioErrorExitCode
+			}
+
+			return true
+		}
+		if result.Error == nil {
+			return false
+		}
+
+		if exitCode == successExitCode {
+			exitCode = exitCodeFromStage(result.Error.Stage)
+		}
+
+		return options.failFast
+	}
+
+	functions := calculator.BuiltInFunctions
+	interpreterOptions := []calculator.Option{
+		calculator.Constants(calculator.BuiltInConstants),
+	}
+	if options.complexMode {
+		functions = functions.Merge(calculator.ComplexFunctions)
+		interpreterOptions = append(
+			interpreterOptions,
+			calculator.Constants(calculator.ComplexConstants),
+		)
+	}
+	if options.financeMode {
+		functions = functions.Merge(calculator.FinanceFunctions)
+	}
+
+	var output bytes.Buffer
+	bufReader := bufio.NewReader(reader)
 	interpreter := calculator.NewInterpreter(
-		calculator.BuiltInVariables,
-		calculator.BuiltInFunctions,
-	)
-	for {
-		input, err := bufStdin.ReadString('\n')
+		models.VariableGroup{},
+		functions,
+		interpreterOptions...,
+	).
+		WithOutput(&output).
+		WithAngleMode(options.angleMode).
+		WithModuleLoader(calculator.DirectoryModuleLoader(options.moduleDirectory))
+	if options.hasSeed {
+		interpreter = interpreter.WithSeed(options.seed)
+	}
+	for lineNumber := 1; ; lineNumber++ {
+		input, err := bufReader.ReadString('\n')
+		if err == io.EOF && input == "" {
+			break
+		}
+		if err != nil && err != io.EOF {
+			handleResult(result{Line: lineNumber, Error: newResultError(err, "", 0)})
+
+			// reading can't be continued after an I/O error
+			return ioErrorExitCode
+		}
+
+		line := strings.TrimRight(input, "\r\n")
+		code := tokenizer.RemoveComment(line)
+		variable, _ := tokenizer.ExtractVariable(code)
+		if constant, ok := tokenizer.ExtractConstant(variable); ok {
+			variable = constant
+		}
+		value, err := interpreter.Interpret(line)
+		printedOutput := output.String()
+		output.Reset()
+
 		if err != nil {
-			if err == io.EOF {
+			if err == calculator.ErrNoCode {
+				continue
+			}
+			if err == calculator.ErrNoValue {
+				stop := handleResult(result{Line: lineNumber, Output: printedOutput})
+				if stop {
+					break
+				}
+
+				continue
+			}
+
+			stop := handleResult(result{
+				Line:     lineNumber,
+				Variable: variable,
+				Error:    newResultError(err, code, findCodeOffset(code)),
+			})
+			if stop {
 				break
 			}
 
-			printError(err)
 			continue
 		}
 
-		number, err := interpreter.Interpret(input)
-		if err != nil {
-			if err != calculator.ErrNoCode {
-				printError(err)
+		stop := handleResult(result{
+			Line:     lineNumber,
+			Variable: variable,
+			Type:     value.Kind.String(),
+			Result:   newResultValue(value),
+		})
+		if stop {
+			break
+		}
+	}
+
+	return exitCode
+}
+
+// it finds the offset of the tokenized part of the code, which positions
+// of tokenization errors are relative to; like the interpreter, it tokenizes
+// the value before the indexes, so the first failed part is found
+func findCodeOffset(code string) int {
+	variable, expression := tokenizer.ExtractVariable(code)
+	expressionOffset := len(code) - len(expression)
+	if variable == "" {
+		if arguments, ok := tokenizer.ExtractKeyword(expression, "print"); ok {
+			return len(code) - len(arguments)
+		}
+		if arguments, ok := tokenizer.ExtractKeyword(expression, "import"); ok {
+			trimmedArguments := strings.TrimLeftFunc(arguments, unicode.IsSpace)
+			if arguments != trimmedArguments &&
+				strings.HasPrefix(trimmedArguments, "\"") {
+				return len(code) - len(trimmedArguments)
 			}
-			continue
 		}
 
-		fmt.Println(number)
+		return expressionOffset
+	}
+
+	valueCode, _, _ := tokenizer.SplitAtKeyword(expression, "to")
+	if !canTokenize(valueCode) {
+		return expressionOffset
+	}
+
+	if constant, ok := tokenizer.ExtractConstant(variable); ok {
+		variable = constant
+	}
+
+	_, indexesCodes, err := tokenizer.ExtractIndexes(variable)
+	if err != nil {
+		return expressionOffset
+	}
+
+	// the variable is trimmed and precedes the equal sign
+	variablePart := code[:len(code)-len(expression)-1]
+	variableOffset :=
+		len(strings.TrimRightFunc(variablePart, unicode.IsSpace)) - len(variable)
+	indexEnd := 0
+	for _, indexCode := range indexesCodes {
+		indexStart := indexEnd + strings.IndexRune(variable[indexEnd:], '[') + 1
+		if !canTokenize(indexCode) {
+			return variableOffset + indexStart
+		}
+
+		indexEnd = indexStart + len(indexCode) + 1
 	}
+
+	return expressionOffset
+}
+
+func canTokenize(code string) bool {
+	var codeTokenizer tokenizer.Tokenizer
+	if _, err := codeTokenizer.Tokenize(code); err != nil {
+		return false
+	}
+
+	_, err := codeTokenizer.Finalize()
+	return err == nil
 }
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errReader struct{}
+
+func (errReader) Read(buffer []byte) (int, error) {
+	return 0, errors.New("read error")
+}
+
+func TestFindCodeOffset(test *testing.T) {
+	type args struct {
+		code string
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantOffset int
+	}{
+		{
+			name:       "expression",
+			args:       args{code: "2 @ 3"},
+			wantOffset: 0,
+		},
+		{
+			name:       "assignment",
+			args:       args{code: "x = 2 @ 3"},
+			wantOffset: 3,
+		},
+		{
+			name:       "print statement",
+			args:       args{code: `print "a", 2 @ 3`},
+			wantOffset: 5,
+		},
+		{
+			name:       "import statement",
+			args:       args{code: `import  "a" @ b`},
+			wantOffset: 8,
+		},
+		{
+			name:       "value before indexes",
+			args:       args{code: "a[1 @ 2] = 2 @ 3"},
+			wantOffset: 10,
+		},
+		{
+			name:       "index",
+			args:       args{code: "a[1 @ 2] = 3"},
+			wantOffset: 2,
+		},
+		{
+			name:       "index of the constant",
+			args:       args{code: "const a[0] [1 @ 2] = 3"},
+			wantOffset: 12,
+		},
+		{
+			name:       "index with spaces",
+			args:       args{code: " a [0][ 2 @ ]  = 3"},
+			wantOffset: 7,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotOffset := findCodeOffset(testCase.args.code)
+
+			assert.Equal(test, testCase.wantOffset, gotOffset)
+		})
+	}
+}
+
+func TestRun(test *testing.T) {
+	type args struct {
+		format  string
+		options options
+		input   string
+		reader  io.Reader // it replaces the input if it's set
+	}
+
+	testsCases := []struct {
+		name          string
+		args          args
+		wantOutput    string
+		wantErrOutput string
+		wantExitCode  int
+	}{
+		{
+			name: "success with the text format",
+			args: args{
+				format:  "text",
+				options: options{},
+				input:   "x = 2\n// comment\nx * 3\nprint \"x = \", x, \"\\n\"",
+			},
+			wantOutput:    "2\n6\nx = 2\n",
+			wantErrOutput: "",
+			wantExitCode:  successExitCode,
+		},
+		{
+			name: "success with the JSON format",
+			args: args{
+				format:  "json",
+				options: options{},
+				input:   "x = 2\n\"s\"\n[1, 2]\n",
+			},
+			wantOutput: "[\n" +
+				"  {\n" +
+				"    \"line\": 1,\n" +
+				"    \"variable\": \"x\",\n" +
+				"    \"type\": \"number\",\n" +
+				"    \"result\": 2\n" +
+				"  },\n" +
+				"  {\n" +
+				"    \"line\": 2,\n" +
+				"    \"type\": \"string\",\n" +
+				"    \"result\": \"s\"\n" +
+				"  },\n" +
+				"  {\n" +
+				"    \"line\": 3,\n" +
+				"    \"type\": \"array\",\n" +
+				"    \"result\": [\n" +
+				"      1,\n" +
+				"      2\n" +
+				"    ]\n" +
+				"  }\n" +
+				"]\n",
+			wantErrOutput: "",
+			wantExitCode:  successExitCode,
+		},
+		{
+			name: "success with the JSON Lines format",
+			args: args{
+				format:  "jsonl",
+				options: options{},
+				input:   "x = 2\r\nprint x\n",
+			},
+			wantOutput: `{"line":1,"variable":"x","type":"number","result":2}` + "\n" +
+				`{"line":2,"output":"2"}` + "\n",
+			wantErrOutput: "",
+			wantExitCode:  successExitCode,
+		},
+		{
+			name: "success with the quiet mode",
+			args: args{
+				format:  "text",
+				options: options{quiet: true},
+				input:   "x = 2\nx + 1\nprint x, \"\\n\"",
+			},
+			wantOutput:    "3\n2\n",
+			wantErrOutput: "",
+			wantExitCode:  successExitCode,
+		},
+		{
+			name: "success with the modes",
+			args: args{
+				format: "text",
+				options: options{
+					complexMode: true,
+					financeMode: true,
+					angleMode:   calculator.DegreeMode,
+				},
+				input: "sqrt(0 - 4)\nsin(90)\nfv(0, 2, 0 - 1)",
+			},
+			wantOutput:    "0+2i\n1\n2\n",
+			wantErrOutput: "",
+			wantExitCode:  successExitCode,
+		},
+		{
+			name: "error with the constants",
+			args: args{
+				format:  "text",
+				options: options{complexMode: true},
+				input:   "pi = 3\ni = 2\n3 + 4 i",
+			},
+			wantOutput: "3+4i\n",
+			wantErrOutput: "error: unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"constant pi can't be reassigned\n" +
+				"error: unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"constant i can't be reassigned\n",
+			wantExitCode: syntaxErrorExitCode,
+		},
+		{
+			name: "error with the tokenization stage",
+			args: args{
+				format:  "jsonl",
+				options: options{},
+				input:   `print "a", 2 @ 3`,
+			},
+			wantOutput: `{"line":1,"error":{` +
+				`"stage":"tokenization",` +
+				`"position":13,` +
+				`"message":"unable to print: unable to tokenize the code: ` +
+				`unknown symbol '@' at position 8"}}` + "\n",
+			wantErrOutput: "",
+			wantExitCode:  syntaxErrorExitCode,
+		},
+		{
+			// positions are reported for tokenization errors only
+			name: "error with the translation stage without the position",
+			args: args{
+				format:  "jsonl",
+				options: options{},
+				input:   "x = (2 + 3",
+			},
+			wantOutput: `{"line":1,"variable":"x","error":{` +
+				`"stage":"translation",` +
+				`"message":"unable to finalize the calculator: ` +
+				`unable to finalize the translator: ` +
+				`missed pair for token {Kind:8 Value:(}"}}` + "\n",
+			wantErrOutput: "",
+			wantExitCode:  syntaxErrorExitCode,
+		},
+		{
+			name: "error with the translation stage",
+			args: args{
+				format:  "text",
+				options: options{},
+				input:   "(2 + 3",
+			},
+			wantOutput: "",
+			wantErrOutput: "error: unable to finalize the calculator: " +
+				"unable to finalize the translator: " +
+				"missed pair for token {Kind:8 Value:(}\n",
+			wantExitCode: syntaxErrorExitCode,
+		},
+		{
+			name: "error with the missed operands",
+			args: args{
+				format:  "text",
+				options: options{},
+				input:   "x = 2 +\nprint 1,",
+			},
+			wantOutput: "",
+			wantErrOutput: "error: unable to finalize the calculator: " +
+				"unable to finalize the translator: " +
+				"missed operand for token {Kind:0 Value:+}\n" +
+				"error: unable to print: " +
+				"unable to split the arguments: argument #1 is empty\n",
+			wantExitCode: syntaxErrorExitCode,
+		},
+		{
+			name: "error with the evaluation stage",
+			args: args{
+				format:  "text",
+				options: options{},
+				input:   "y + 1\n2 + 3",
+			},
+			wantOutput: "5\n",
+			wantErrOutput: "error: unable to calculate the code: " +
+				"unable to evaluate the commands: unknown variable in command " +
+				"{Kind:1 Operand:y ArgumentCount:0} with number #0\n",
+			wantExitCode: runtimeErrorExitCode,
+		},
+		{
+			name: "error with the input stage",
+			args: args{
+				format:  "jsonl",
+				options: options{},
+				reader:  io.MultiReader(strings.NewReader("2\n"), errReader{}),
+			},
+			wantOutput: `{"line":1,"type":"number","result":2}` + "\n" +
+				`{"line":2,"error":{"stage":"input","message":"read error"}}` + "\n",
+			wantErrOutput: "",
+			wantExitCode:  ioErrorExitCode,
+		},
+		{
+			name: "error with several stages",
+			args: args{
+				format:  "text",
+				options: options{},
+				input:   "y + 1\n2 @ 3",
+			},
+			wantOutput: "",
+			wantErrOutput: "error: unable to calculate the code: " +
+				"unable to evaluate the commands: unknown variable in command " +
+				"{Kind:1 Operand:y ArgumentCount:0} with number #0\n" +
+				"error: unable to calculate the code: " +
+				"unable to tokenize the code: unknown symbol '@' at position 2\n",
+			wantExitCode: runtimeErrorExitCode,
+		},
+		{
+			name: "error with the fail-fast mode",
+			args: args{
+				format:  "text",
+				options: options{failFast: true},
+				input:   "2 @ 3\ny + 1\n2 + 3",
+			},
+			wantOutput: "",
+			wantErrOutput: "error: unable to calculate the code: " +
+				"unable to tokenize the code: unknown symbol '@' at position 2\n",
+			wantExitCode: syntaxErrorExitCode,
+		},
+		{
+			name: "error with the quiet mode",
+			args: args{
+				format:  "text",
+				options: options{quiet: true},
+				input:   "x = y",
+			},
+			wantOutput: "",
+			wantErrOutput: "error: unable to finalize the calculator: " +
+				"unable to evaluate the commands: unknown variable in command " +
+				"{Kind:1 Operand:y ArgumentCount:0} with number #0\n",
+			wantExitCode: runtimeErrorExitCode,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			var output, errOutput bytes.Buffer
+			resultWriter, err :=
+				newResultWriter(testCase.args.format, &output, &errOutput)
+			require.NoError(test, err)
+
+			reader := testCase.args.reader
+			if reader == nil {
+				reader = strings.NewReader(testCase.args.input)
+			}
+
+			gotExitCode := run(reader, resultWriter, testCase.args.options)
+			require.NoError(test, resultWriter.Close())
+
+			assert.Equal(test, testCase.wantOutput, output.String())
+			assert.Equal(test, testCase.wantErrOutput, errOutput.String())
+			assert.Equal(test, testCase.wantExitCode, gotExitCode)
+		})
+	}
+}
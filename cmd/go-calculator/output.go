@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/irenicaa/go-calculator/v2"
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+)
+
+const inputStage = "input"
+
+type resultValue models.Value
+
+func newResultValue(value models.Value) *resultValue {
+	result := resultValue(value)
+	return &result
+}
+
+func (value resultValue) MarshalJSON() ([]byte, error) {
+	switch value.Kind {
+	case models.StringValue:
+		return json.Marshal(value.Text)
+	case models.ComplexValue, models.QuantityValue:
+		// JSON has no representation for complex numbers and quantities
+		return json.Marshal(models.Value(value).String())
+	case models.ArrayValue:
+		elements := make([]resultValue, 0, len(value.Elements))
+		for _, element := range value.Elements {
+			elements = append(elements, resultValue(element))
+		}
+
+		return json.Marshal(elements)
+	}
+
+	// JSON has no representation for NaN and infinities
+	if math.IsNaN(value.Number) || math.IsInf(value.Number, 0) {
+		return json.Marshal(models.Value(value).String())
+	}
+
+	return json.Marshal(value.Number)
+}
+
+type resultError struct {
+	Stage string `json:"stage"`
+	// it's specified for tokenization errors only, because tokens
+	// and commands don't keep their offsets in the code; messages
+	// of other errors refer to the numbers of the tokens or the commands
+	Position *int   `json:"position,omitempty"`
+	Message  string `json:"message"`
+}
+
+func newResultError(err error, line string, codeOffset int) *resultError {
+	resultErr := &resultError{Stage: inputStage, Message: err.Error()}
+
+	var calculatorErr *calculator.Error
+	if errors.As(err, &calculatorErr) {
+		resultErr.Stage = string(calculatorErr.Stage)
+	}
+
+	var tokenizerErr tokenizer.Error
+	if errors.As(err, &tokenizerErr) {
+		position := len(line)
+		if tokenizerErr.Position != -1 {
+			position = codeOffset + tokenizerErr.Position
+		}
+
+		resultErr.Position = &position
+	}
+
+	return resultErr
+}
+
+type result struct {
+	Line     int          `json:"line"`
+	Variable string       `json:"variable,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Result   *resultValue `json:"result,omitempty"`
+	Output   string       `json:"output,omitempty"`
+	Error    *resultError `json:"error,omitempty"`
+}
+
+type resultWriter interface {
+	WriteResult(result result) error
+	Close() error
+}
+
+func newResultWriter(
+	format string,
+	writer io.Writer,
+	errWriter io.Writer,
+) (resultWriter, error) {
+	switch format {
+	case "text":
+		return textResultWriter{writer: writer, errWriter: errWriter}, nil
+	case "json":
+		return &jsonResultWriter{writer: writer, results: []result{}}, nil
+	case "jsonl":
+		return jsonLinesResultWriter{encoder: json.NewEncoder(writer)}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+type textResultWriter struct {
+	writer    io.Writer
+	errWriter io.Writer
+}
+
+func (resultWriter textResultWriter) WriteResult(result result) error {
+	if result.Output != "" {
+		if _, err := io.WriteString(resultWriter.writer, result.Output); err != nil {
+			return err
+		}
+	}
+	if result.Error != nil {
+		_, err := fmt.Fprintf(
+			resultWriter.errWriter,
+			"error: %s\n",
+			result.Error.Message,
+		)
+		return err
+	}
+
+	if result.Result == nil {
+		return nil
+	}
+
+	_, err := fmt.Fprintln(resultWriter.writer, models.Value(*result.Result))
+	return err
+}
+
+func (resultWriter textResultWriter) Close() error {
+	return nil
+}
+
+type jsonResultWriter struct {
+	writer  io.Writer
+	results []result
+}
+
+func (resultWriter *jsonResultWriter) WriteResult(result result) error {
+	resultWriter.results = append(resultWriter.results, result)
+	return nil
+}
+
+func (resultWriter *jsonResultWriter) Close() error {
+	encoder := json.NewEncoder(resultWriter.writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(resultWriter.results)
+}
+
+type jsonLinesResultWriter struct {
+	encoder *json.Encoder
+}
+
+func (resultWriter jsonLinesResultWriter) WriteResult(result result) error {
+	return resultWriter.encoder.Encode(result)
+}
+
+func (resultWriter jsonLinesResultWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,187 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// integer powers are calculated by multiplication up to this exponent,
+// because math/cmplx produces rounding errors for them, e.g. for i^2
+const maxIntegerExponent = 1 << 10
+
+// ComplexVariables contains the values of ComplexConstants as ordinary
+// variables, so they may be reassigned.
+//
+// Deprecated: pass Constants(ComplexConstants) to NewInterpreter instead,
+// so the constants can't be reassigned.
+var ComplexVariables = ComplexConstants.Variables()
+
+// ComplexConstants and ComplexFunctions are intended to be passed
+// by the Constants option and merged into BuiltInFunctions correspondingly
+// for enabling of the complex number mode
+var (
+	ComplexConstants = models.ConstantGroup{
+		"i": models.NewComplex(1i),
+	}
+	ComplexFunctions = withRealHandlers(models.FunctionGroup{
+		// operators
+		"+": {
+			Arity: 2,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return arguments[0] + arguments[1], nil
+			},
+		},
+		"-": {
+			Arity: 2,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return arguments[0] - arguments[1], nil
+			},
+		},
+		"*": {
+			Arity: 2,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return arguments[0] * arguments[1], nil
+			},
+		},
+		"/": {
+			Arity: 2,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				// keep the real semantics of division by zero
+				if isReal(arguments...) {
+					quotient := real(arguments[0]) / real(arguments[1])
+					return complex(quotient, 0), nil
+				}
+
+				return arguments[0] / arguments[1], nil
+			},
+		},
+		"^": {
+			Arity: 2,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				// the real power is more precise, but it's defined
+				// for a negative base only with an integer exponent
+				base, exponent := real(arguments[0]), real(arguments[1])
+				isIntegerExponent := exponent == math.Trunc(exponent)
+				if isReal(arguments...) && (base >= 0 || isIntegerExponent) {
+					return complex(math.Pow(base, exponent), 0), nil
+				}
+
+				if imag(arguments[1]) == 0 && isIntegerExponent &&
+					math.Abs(exponent) <= maxIntegerExponent {
+					return integerPower(arguments[0], int(exponent)), nil
+				}
+
+				return cmplx.Pow(arguments[0], arguments[1]), nil
+			},
+		},
+
+		// functions
+		"abs": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return complex(cmplx.Abs(arguments[0]), 0), nil
+			},
+		},
+		"arg": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return complex(cmplx.Phase(arguments[0]), 0), nil
+			},
+		},
+		"conj": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return cmplx.Conj(arguments[0]), nil
+			},
+		},
+		"re": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return complex(real(arguments[0]), 0), nil
+			},
+		},
+		"im": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return complex(imag(arguments[0]), 0), nil
+			},
+		},
+		"sqrt": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return cmplx.Sqrt(arguments[0]), nil
+			},
+		},
+		"exp": {
+			Arity: 1,
+			ComplexHandler: func(arguments []complex128) (complex128, error) {
+				return cmplx.Exp(arguments[0]), nil
+			},
+		},
+		"log": {Arity: 1, MaxArity: 2, ComplexHandler: complexLogarithm},
+	})
+)
+
+// it adds the handlers of the built-in functions with the same names,
+// so quantities are still accepted in the complex number mode
+func withRealHandlers(functions models.FunctionGroup) models.FunctionGroup {
+	for name, function := range functions {
+		if realFunction, ok := BuiltInFunctions[name]; ok {
+			function.Handler = realFunction.Handler
+			function.DimensionHandler = realFunction.DimensionHandler
+			functions[name] = function
+		}
+	}
+
+	return functions
+}
+
+// log(x[, base]) like the real logarithm, but it also accepts
+// negative and complex numbers
+func complexLogarithm(arguments []complex128) (complex128, error) {
+	if len(arguments) == 1 {
+		return cmplx.Log(arguments[0]), nil
+	}
+
+	base := arguments[1]
+	if base == 0 || base == 1 {
+		return 0, errors.New("argument #1 should be nonzero and not equal to 1")
+	}
+	// the real logarithm is more precise
+	if isReal(arguments...) && real(arguments[0]) > 0 && real(base) > 0 {
+		return complex(math.Log(real(arguments[0]))/math.Log(real(base)), 0), nil
+	}
+
+	return cmplx.Log(arguments[0]) / cmplx.Log(base), nil
+}
+
+func isReal(numbers ...complex128) bool {
+	for _, number := range numbers {
+		if imag(number) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// it uses the exponentiation by squaring
+func integerPower(base complex128, exponent int) complex128 {
+	if exponent < 0 {
+		return 1 / integerPower(base, -exponent)
+	}
+
+	result := complex128(1)
+	for ; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			result *= base
+		}
+
+		base *= base
+	}
+
+	return result
+}
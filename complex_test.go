@@ -0,0 +1,325 @@
+package calculator
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplexConstants(test *testing.T) {
+	assert.Equal(test, models.NewComplex(1i), ComplexConstants["i"])
+}
+
+func TestComplexFunctions(test *testing.T) {
+	type args struct {
+		name      string
+		arguments []models.Value
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantArity  int
+		wantResult models.Value
+		wantErr    string
+	}{
+		{
+			name: "+",
+			args: args{
+				name: "+",
+				arguments: []models.Value{
+					models.NewNumber(3),
+					models.NewComplex(4i),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewComplex(3 + 4i),
+			wantErr:    "",
+		},
+		{
+			name: "-/with a real result",
+			args: args{
+				name: "-",
+				arguments: []models.Value{
+					models.NewComplex(3 + 4i),
+					models.NewComplex(4i),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(3),
+			wantErr:    "",
+		},
+		{
+			name: "*",
+			args: args{
+				name: "*",
+				arguments: []models.Value{
+					models.NewComplex(1 + 2i),
+					models.NewComplex(3 - 4i),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewComplex(11 + 2i),
+			wantErr:    "",
+		},
+		{
+			name: "//with complex numbers",
+			args: args{
+				name: "/",
+				arguments: []models.Value{
+					models.NewComplex(1 + 2i),
+					models.NewComplex(3 - 4i),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewComplex(-0.2 + 0.4i),
+			wantErr:    "",
+		},
+		{
+			name: "//with division by zero",
+			args: args{
+				name: "/",
+				arguments: []models.Value{
+					models.NewNumber(1),
+					models.NewNumber(0),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(math.Inf(1)),
+			wantErr:    "",
+		},
+		{
+			name: "^/with an integer exponent",
+			args: args{
+				name: "^",
+				arguments: []models.Value{
+					models.NewComplex(1i),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(-1),
+			wantErr:    "",
+		},
+		{
+			name: "^/with a negative integer exponent",
+			args: args{
+				name: "^",
+				arguments: []models.Value{
+					models.NewComplex(2i),
+					models.NewNumber(-2),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(-0.25),
+			wantErr:    "",
+		},
+		{
+			name: "^/with a negative base",
+			args: args{
+				name: "^",
+				arguments: []models.Value{
+					models.NewNumber(-4),
+					models.NewNumber(0.5),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewComplex(cmplx.Pow(-4, 0.5)),
+			wantErr:    "",
+		},
+		{
+			name: "^/with real numbers",
+			args: args{
+				name: "^",
+				arguments: []models.Value{
+					models.NewNumber(2),
+					models.NewNumber(0.5),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.NewNumber(math.Sqrt2),
+			wantErr:    "",
+		},
+		{
+			name: "abs",
+			args: args{
+				name: "abs",
+				arguments: []models.Value{
+					models.NewComplex(3 + 4i),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(5),
+			wantErr:    "",
+		},
+		{
+			name: "arg",
+			args: args{
+				name: "arg",
+				arguments: []models.Value{
+					models.NewComplex(1i),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(math.Pi / 2),
+			wantErr:    "",
+		},
+		{
+			name: "conj",
+			args: args{
+				name: "conj",
+				arguments: []models.Value{
+					models.NewComplex(3 + 4i),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewComplex(3 - 4i),
+			wantErr:    "",
+		},
+		{
+			name: "re",
+			args: args{
+				name: "re",
+				arguments: []models.Value{
+					models.NewComplex(3 + 4i),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(3),
+			wantErr:    "",
+		},
+		{
+			name: "im",
+			args: args{
+				name: "im",
+				arguments: []models.Value{
+					models.NewComplex(3 + 4i),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(4),
+			wantErr:    "",
+		},
+		{
+			name: "sqrt",
+			args: args{
+				name: "sqrt",
+				arguments: []models.Value{
+					models.NewNumber(-4),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewComplex(2i),
+			wantErr:    "",
+		},
+		{
+			name: "exp",
+			args: args{
+				name: "exp",
+				arguments: []models.Value{
+					models.NewComplex(1i),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewComplex(cmplx.Exp(1i)),
+			wantErr:    "",
+		},
+		{
+			name: "log",
+			args: args{
+				name: "log",
+				arguments: []models.Value{
+					models.NewNumber(-1),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewComplex(math.Pi * 1i),
+			wantErr:    "",
+		},
+		{
+			name: "log/with the base",
+			args: args{
+				name: "log",
+				arguments: []models.Value{
+					models.NewNumber(8),
+					models.NewNumber(2),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(3),
+			wantErr:    "",
+		},
+		{
+			name: "log/with the negative base",
+			args: args{
+				name: "log",
+				arguments: []models.Value{
+					models.NewNumber(-1),
+					models.NewNumber(-1),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.NewNumber(1),
+			wantErr:    "",
+		},
+		{
+			name: "log/error with the base",
+			args: args{
+				name: "log",
+				arguments: []models.Value{
+					models.NewNumber(8),
+					models.NewNumber(1),
+				},
+			},
+			wantArity:  1,
+			wantResult: models.Value{},
+			wantErr:    "argument #1 should be nonzero and not equal to 1",
+		},
+		{
+			name: "+/error",
+			args: args{
+				name: "+",
+				arguments: []models.Value{
+					models.NewComplex(1i),
+					models.NewString("test"),
+				},
+			},
+			wantArity:  2,
+			wantResult: models.Value{},
+			wantErr:    "argument #1 has type string, but complex is expected",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotFunction, gotOk := ComplexFunctions[testCase.args.name]
+			require.True(test, gotOk)
+
+			gotResult, gotErr := gotFunction.Call(testCase.args.arguments)
+
+			assert.Equal(test, testCase.wantArity, gotFunction.Arity)
+			assert.Equal(test, testCase.wantResult.Kind, gotResult.Kind)
+			assert.InDelta(test, testCase.wantResult.Number, gotResult.Number, 1e-6)
+			assert.InDelta(
+				test,
+				real(testCase.wantResult.Complex),
+				real(gotResult.Complex),
+				1e-6,
+			)
+			assert.InDelta(
+				test,
+				imag(testCase.wantResult.Complex),
+				imag(gotResult.Complex),
+				1e-6,
+			)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
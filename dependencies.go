@@ -0,0 +1,168 @@
+package calculator
+
+import (
+	"sort"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+)
+
+// Dependencies are names used by a statement; the names are sorted
+// and unique.
+type Dependencies struct {
+	// it's a name without indexes and without the const keyword;
+	// empty for statements without assignment
+	AssignedVariable string
+	// they include constants and units, because they're indistinguishable
+	// from variables before evaluation, but exclude variables bound
+	// by functions, e.g. x in sum(x ^ 2, x, 1, 3); see the BoundVariableNumber
+	// field of models.Function
+	Variables []string
+	// they exclude operators
+	Functions []string
+}
+
+// FindDependencies finds names used by the statement of the interpreter
+// without evaluating it, e.g. for validation of forms or for bindings of UI.
+func FindDependencies(
+	input string,
+	functions models.FunctionProvider,
+) (Dependencies, error) {
+	statement, err := translateStatement(input, functions)
+	if err != nil {
+		return Dependencies{}, err
+	}
+
+	var assignedVariable string
+	if statement.variable != "" {
+		assignedVariable, _, _ = tokenizer.ExtractIndexes(statement.variable)
+	}
+
+	variables, functionSet := collectDependencies(statement.commands, functions)
+	return Dependencies{
+		AssignedVariable: assignedVariable,
+		Variables:        sortNames(variables),
+		Functions:        sortNames(functionSet),
+	}, nil
+}
+
+func collectDependencies(
+	commands []models.Command,
+	provider models.FunctionProvider,
+) (variables map[string]struct{}, functions map[string]struct{}) {
+	variables, functions = map[string]struct{}{}, map[string]struct{}{}
+	// unevaluated arguments waiting for their function
+	var expressions [][]models.Command
+	for index := 0; index < len(commands); index++ {
+		command := commands[index]
+		switch command.Kind {
+		case models.PushVariableCommand, models.PushUnitCommand:
+			variables[command.Operand] = struct{}{}
+		case models.StartExpressionCommand:
+			end := findExpressionEnd(commands, index)
+			expressions = append(expressions, commands[index+1:end])
+			index = end
+		case models.CallFunctionCommand:
+			if !isOperator(command.Operand) {
+				functions[command.Operand] = struct{}{}
+			}
+
+			function, _ := provider.Function(command.Operand)
+			count := function.LazyArgumentCount
+			if command.ArgumentCount != 0 && command.ArgumentCount < count {
+				count = command.ArgumentCount
+			}
+			if len(expressions) < count {
+				count = len(expressions)
+			}
+			if count == 0 {
+				continue
+			}
+
+			arguments := expressions[len(expressions)-count:]
+			expressions = expressions[:len(expressions)-count]
+			boundIndex, ok := function.BoundVariableIndex(command.ArgumentCount)
+			if !ok || boundIndex >= len(arguments) {
+				boundIndex = -1
+			}
+
+			collectArgumentDependencies(
+				arguments,
+				boundIndex,
+				provider,
+				variables,
+				functions,
+			)
+		}
+	}
+
+	// the remaining expressions are incorrect, but their names are used anyway
+	collectArgumentDependencies(expressions, -1, provider, variables, functions)
+	return variables, functions
+}
+
+// the argument with the bound index names the variable bound by the function,
+// like x in sum(x ^ 2, x, 1, 3), so it's excluded from the other arguments;
+// -1 means that there's no such argument
+func collectArgumentDependencies(
+	arguments [][]models.Command,
+	boundIndex int,
+	provider models.FunctionProvider,
+	variables map[string]struct{},
+	functions map[string]struct{},
+) {
+	var boundVariable string
+	if boundIndex != -1 {
+		boundArgument := arguments[boundIndex]
+		if len(boundArgument) == 1 &&
+			boundArgument[0].Kind == models.PushVariableCommand {
+			boundVariable = boundArgument[0].Operand
+			arguments = append(
+				append([][]models.Command{}, arguments[:boundIndex]...),
+				arguments[boundIndex+1:]...,
+			)
+		}
+	}
+
+	for _, argument := range arguments {
+		argumentVariables, argumentFunctions :=
+			collectDependencies(argument, provider)
+		for name := range argumentVariables {
+			if name != boundVariable {
+				variables[name] = struct{}{}
+			}
+		}
+		for name := range argumentFunctions {
+			functions[name] = struct{}{}
+		}
+	}
+}
+
+// it returns an index of the end command matching the start command
+// or the command count if there's no such command
+func findExpressionEnd(commands []models.Command, start int) int {
+	depth := 0
+	for index := start; index < len(commands); index++ {
+		switch commands[index].Kind {
+		case models.StartExpressionCommand:
+			depth++
+		case models.EndExpressionCommand:
+			depth--
+			if depth == 0 {
+				return index
+			}
+		}
+	}
+
+	return len(commands)
+}
+
+func sortNames(names map[string]struct{}) []string {
+	var sortedNames []string
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	return sortedNames
+}
@@ -0,0 +1,117 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDependencies(test *testing.T) {
+	testsCases := []struct {
+		name             string
+		input            string
+		wantDependencies Dependencies
+		wantErr          string
+	}{
+		{
+			name:  "with the expression",
+			input: "y + sqrt(x) * abs(x) // z",
+			wantDependencies: Dependencies{
+				Variables: []string{"x", "y"},
+				Functions: []string{"abs", "sqrt"},
+			},
+			wantErr: "",
+		},
+		{
+			name:  "with the assignment",
+			input: "const total[i] = price * count to km",
+			wantDependencies: Dependencies{
+				AssignedVariable: "total",
+				Variables:        []string{"count", "i", "price"},
+				Functions:        nil,
+			},
+			wantErr: "",
+		},
+		{
+			name:  "with the print statement",
+			input: "print \"x = \", round(x), y",
+			wantDependencies: Dependencies{
+				Variables: []string{"x", "y"},
+				Functions: []string{"round"},
+			},
+			wantErr: "",
+		},
+		{
+			name:  "with arrays in the print statement",
+			input: "print [x, 2], y[i][j]",
+			wantDependencies: Dependencies{
+				Variables: []string{"i", "j", "x", "y"},
+			},
+			wantErr: "",
+		},
+		{
+			name:             "with the mode statement",
+			input:            "mode degrees",
+			wantDependencies: Dependencies{},
+			wantErr:          "",
+		},
+		{
+			name:  "with the bound variable",
+			input: "s = sum(a * x ^ 2, x, 1, n) + x",
+			wantDependencies: Dependencies{
+				AssignedVariable: "s",
+				Variables:        []string{"a", "n", "x"},
+				Functions:        []string{"sum"},
+			},
+			wantErr: "",
+		},
+		{
+			name:  "with the nested bound variables",
+			input: "sum(prod(i * j + sqrt(k), j, 1, i), i, 1, n)",
+			wantDependencies: Dependencies{
+				Variables: []string{"k", "n"},
+				Functions: []string{"prod", "sqrt", "sum"},
+			},
+			wantErr: "",
+		},
+		{
+			name:  "with the equation",
+			input: "solve(a * b - 1, b, 1)",
+			wantDependencies: Dependencies{
+				Variables: []string{"a"},
+				Functions: []string{"solve"},
+			},
+			wantErr: "",
+		},
+		{
+			name:  "with the linear system",
+			input: "x = solve(A, b)",
+			wantDependencies: Dependencies{
+				AssignedVariable: "x",
+				Variables:        []string{"A", "b"},
+				Functions:        []string{"solve"},
+			},
+			wantErr: "",
+		},
+		{
+			name:             "with the incorrect code",
+			input:            "sqrt(x",
+			wantDependencies: Dependencies{},
+			wantErr: "unable to finalize the translator: " +
+				"missed pair for token {Kind:8 Value:(}",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotDependencies, gotErr :=
+				FindDependencies(testCase.input, BuiltInFunctions)
+
+			assert.Equal(test, testCase.wantDependencies, gotDependencies)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
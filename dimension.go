@@ -0,0 +1,72 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// zeros are compatible with any dimension, e.g. in "0 - 5 m"
+func sameDimension(
+	arguments []float64,
+	dimensions []models.Dimension,
+) (models.Dimension, error) {
+	var resultDimension *models.Dimension
+	for index, dimension := range dimensions {
+		if dimension.IsZero() && arguments[index] == 0 {
+			continue
+		}
+
+		if resultDimension == nil {
+			resultDimension = &dimensions[index]
+			continue
+		}
+		if dimension != *resultDimension {
+			return models.Dimension{}, fmt.Errorf(
+				"dimensions are different: %s and %s",
+				*resultDimension,
+				dimension,
+			)
+		}
+	}
+	if resultDimension == nil {
+		return models.Dimension{}, nil
+	}
+
+	return *resultDimension, nil
+}
+
+func productDimension(
+	arguments []float64,
+	dimensions []models.Dimension,
+) (models.Dimension, error) {
+	return dimensions[0].Multiply(dimensions[1]), nil
+}
+
+func quotientDimension(
+	arguments []float64,
+	dimensions []models.Dimension,
+) (models.Dimension, error) {
+	return dimensions[0].Divide(dimensions[1]), nil
+}
+
+func powerDimension(
+	arguments []float64,
+	dimensions []models.Dimension,
+) (models.Dimension, error) {
+	if !dimensions[1].IsZero() {
+		return models.Dimension{}, fmt.Errorf(
+			"exponent has dimension %s, but it should be dimensionless",
+			dimensions[1],
+		)
+	}
+
+	return dimensions[0].Power(arguments[1])
+}
+
+func squareRootDimension(
+	arguments []float64,
+	dimensions []models.Dimension,
+) (models.Dimension, error) {
+	return dimensions[0].Power(0.5)
+}
@@ -0,0 +1,29 @@
+package calculator
+
+// Stage ...
+type Stage string
+
+// ...
+const (
+	TokenizationStage Stage = "tokenization"
+	TranslationStage  Stage = "translation"
+	EvaluationStage   Stage = "evaluation"
+)
+
+// Error describes the stage where calculating failed;
+// it isn't changed after creation, so it may be shared between goroutines.
+type Error struct {
+	Stage   Stage
+	Message string
+	Err     error
+}
+
+// Error ...
+func (err *Error) Error() string {
+	return err.Message + ": " + err.Err.Error()
+}
+
+// Unwrap ...
+func (err *Error) Unwrap() error {
+	return err.Err
+}
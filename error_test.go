@@ -0,0 +1,96 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_Error(test *testing.T) {
+	err := &Error{
+		Stage:   EvaluationStage,
+		Message: "unable to evaluate the commands",
+		Err:     errors.New("value stack is empty"),
+	}
+
+	assert.Equal(
+		test,
+		"unable to evaluate the commands: value stack is empty",
+		err.Error(),
+	)
+	assert.Equal(test, errors.New("value stack is empty"), errors.Unwrap(err))
+}
+
+func TestError_withInterpreter(test *testing.T) {
+	type fields struct {
+		variables models.VariableGroup
+	}
+	type args struct {
+		input string
+	}
+
+	testsCases := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantStage Stage
+	}{
+		{
+			name:      "tokenization",
+			args:      args{input: "2 @ 3"},
+			wantStage: TokenizationStage,
+		},
+		{
+			name:      "tokenization on finalizing",
+			args:      args{input: "2 + ."},
+			wantStage: TokenizationStage,
+		},
+		{
+			name:      "translation",
+			args:      args{input: "2 + 3)"},
+			wantStage: TranslationStage,
+		},
+		{
+			name:      "translation on finalizing",
+			args:      args{input: "(2 + 3"},
+			wantStage: TranslationStage,
+		},
+		{
+			name:      "translation of the indexes",
+			args:      args{input: "x[ = 2"},
+			wantStage: TranslationStage,
+		},
+		{
+			name:      "evaluation",
+			args:      args{input: "x + 3"},
+			wantStage: EvaluationStage,
+		},
+		{
+			name:      "evaluation with a negative index",
+			args:      args{input: "x[0 - 1] = 3"},
+			wantStage: EvaluationStage,
+		},
+		{
+			name: "evaluation with an element of a string",
+			fields: fields{
+				variables: models.VariableGroup{"x": models.NewString("s")},
+			},
+			args:      args{input: "x[0] = 1"},
+			wantStage: EvaluationStage,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			interpreter :=
+				NewInterpreter(testCase.fields.variables, BuiltInFunctions)
+			_, err := interpreter.Interpret(testCase.args.input)
+
+			var gotErr *Error
+			require.True(test, errors.As(err, &gotErr))
+			assert.Equal(test, testCase.wantStage, gotErr.Stage)
+		})
+	}
+}
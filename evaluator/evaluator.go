@@ -1,29 +1,100 @@
 package evaluator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
 
 	"github.com/irenicaa/go-calculator/v2/models"
 	"github.com/irenicaa/go-calculator/v2/models/containers"
+	"github.com/irenicaa/go-calculator/v2/units"
 )
 
-// Evaluator ...
+// Evaluator executes commands on its stack; it isn't safe
+// for concurrent use, but the expressions it makes may be evaluated
+// concurrently, because each evaluation uses a fresh evaluator.
 type Evaluator struct {
-	stack containers.NumberStack
+	stack containers.ValueStack
+	// commands of the current unevaluated expression and the depth
+	// of the expressions nested in it
+	expressionCommands []models.Command
+	expressionDepth    int
+
+	limits Limits
+	// they're shared with evaluators of the expressions
+	commandCount *int64
+	resolver     *cachedResolver
+}
+
+// NewEvaluator returns an evaluator with the limits;
+// the zero value of the Evaluator has no limits.
+func NewEvaluator(limits Limits) Evaluator {
+	return Evaluator{limits: limits, commandCount: new(int64)}
+}
+
+// WithResolver returns a copy of the evaluator that consults the resolver
+// when a variable is missed in the variable group; results of the resolver
+// are cached by the copy, so use a new one for each evaluation
+// if the variables may change.
+func (evaluator Evaluator) WithResolver(resolver VariableResolver) Evaluator {
+	evaluator.resolver = newCachedResolver(resolver)
+	return evaluator
 }
 
 // Evaluate ...
 func (evaluator *Evaluator) Evaluate(
 	commands []models.Command,
 	variables models.VariableGroup,
-	functions models.FunctionGroup,
+	functions models.FunctionProvider,
+) error {
+	return evaluator.EvaluateContext(
+		context.Background(),
+		commands,
+		variables,
+		functions,
+	)
+}
+
+// EvaluateContext stops the evaluation with an error of the context
+// when the context is done, including evaluations of the expressions.
+func (evaluator *Evaluator) EvaluateContext(
+	ctx context.Context,
+	commands []models.Command,
+	variables models.VariableGroup,
+	functions models.FunctionProvider,
+) error {
+	scope := &variableScope{variables: variables}
+	return evaluator.evaluate(ctx, commands, scope, functions)
+}
+
+func (evaluator *Evaluator) evaluate(
+	ctx context.Context,
+	commands []models.Command,
+	scope *variableScope,
+	functions models.FunctionProvider,
 ) error {
 	for commandIndex, command := range commands {
+		if err := evaluator.checkResources(ctx); err != nil {
+			return fmt.Errorf(
+				"unable to execute command %+v with number #%d: %w",
+				command,
+				commandIndex,
+				err,
+			)
+		}
+
+		if evaluator.expressionDepth != 0 {
+			evaluator.collectExpressionCommand(ctx, command, scope, functions)
+			continue
+		}
+
 		switch command.Kind {
 		case models.PushNumberCommand:
-			number, err := strconv.ParseFloat(command.Operand, 64)
+			number, err := parseNumber(command.Operand)
 			if err != nil {
 				return fmt.Errorf(
 					"incorrect number for command %+v with number #%d: %s",
@@ -34,8 +105,28 @@ func (evaluator *Evaluator) Evaluate(
 			}
 
 			evaluator.stack.Push(number)
-		case models.PushVariableCommand:
-			number, ok := variables[command.Operand]
+		case models.PushStringCommand:
+			evaluator.stack.Push(models.NewString(command.Operand))
+		case models.PushVariableCommand, models.PushUnitCommand:
+			value, ok := scope.lookup(command.Operand)
+			if !ok && evaluator.resolver != nil {
+				number, resolved, err := evaluator.resolver.Resolve(command.Operand)
+				if err != nil {
+					return fmt.Errorf(
+						"unable to resolve variable %s in command %+v with number #%d: %w",
+						command.Operand,
+						command,
+						commandIndex,
+						err,
+					)
+				}
+
+				value, ok = models.NewNumber(number), resolved
+			}
+			if !ok && command.Kind == models.PushUnitCommand {
+				// variables shadow units with the same names
+				value, ok = lookupUnit(command.Operand)
+			}
 			if !ok {
 				return fmt.Errorf(
 					"unknown variable in command %+v with number #%d",
@@ -44,9 +135,66 @@ func (evaluator *Evaluator) Evaluate(
 				)
 			}
 
-			evaluator.stack.Push(number)
+			evaluator.stack.Push(value)
+		case models.IndexCommand:
+			index, ok := evaluator.stack.Pop()
+			if !ok {
+				return fmt.Errorf(
+					"value stack is empty for the index in command %+v with number #%d",
+					command,
+					commandIndex,
+				)
+			}
+
+			value, ok := evaluator.stack.Pop()
+			if !ok {
+				return fmt.Errorf(
+					"value stack is empty for the array in command %+v with number #%d",
+					command,
+					commandIndex,
+				)
+			}
+
+			element, err := value.Element(index)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to get the element in command %+v with number #%d: %s",
+					command,
+					commandIndex,
+					err,
+				)
+			}
+
+			evaluator.stack.Push(element)
+		case models.MakeArrayCommand:
+			elementCount, err := strconv.ParseUint(command.Operand, 10, 0)
+			if err != nil {
+				return fmt.Errorf(
+					"incorrect element count for command %+v with number #%d: %s",
+					command,
+					commandIndex,
+					err,
+				)
+			}
+
+			elements := make([]models.Value, elementCount)
+			for elementIndex := int(elementCount) - 1; elementIndex >= 0; elementIndex-- {
+				element, ok := evaluator.stack.Pop()
+				if !ok {
+					return fmt.Errorf(
+						"value stack is empty for element #%d in command %+v with number #%d",
+						elementIndex,
+						command,
+						commandIndex,
+					)
+				}
+
+				elements[elementIndex] = element
+			}
+
+			evaluator.stack.Push(models.NewArray(elements...))
 		case models.CallFunctionCommand:
-			function, ok := functions[command.Operand]
+			function, ok := functions.Function(command.Operand)
 			if !ok {
 				return fmt.Errorf(
 					"unknown function in command %+v with number #%d",
@@ -55,52 +203,193 @@ func (evaluator *Evaluator) Evaluate(
 				)
 			}
 
-			arguments := []float64{}
-			for argumentIndex := 0; argumentIndex < function.Arity; argumentIndex++ {
-				number, ok := evaluator.stack.Pop()
+			// operators are called without parentheses, so they have
+			// no argument count, but calls of functions always have it
+			argumentCount := function.Arity
+			if !isOperator(command.Operand) {
+				argumentCount = command.ArgumentCount
+				if err := function.CheckArgumentCount(argumentCount); err != nil {
+					return fmt.Errorf(
+						"incorrect argument count for command %+v with number #%d: %s",
+						command,
+						commandIndex,
+						err,
+					)
+				}
+			}
+
+			arguments := []models.Value{}
+			for argumentIndex := 0; argumentIndex < argumentCount; argumentIndex++ {
+				value, ok := evaluator.stack.Pop()
 				if !ok {
 					return fmt.Errorf(
-						"number stack is empty for argument #%d in command %+v with number #%d",
+						"value stack is empty for argument #%d in command %+v with number #%d",
 						argumentIndex,
 						command,
 						commandIndex,
 					)
 				}
 
-				arguments = append(arguments, number)
+				arguments = append(arguments, value)
 			}
 			reverseArguments(arguments)
 
-			number, err := function.Handler(arguments)
+			value, err := function.Call(arguments)
 			if err != nil {
 				return fmt.Errorf(
-					"unable to call the function from command %+v with number #%d: %s",
+					"unable to call the function from command %+v with number #%d: %w",
 					command,
 					commandIndex,
 					err,
 				)
 			}
 
-			evaluator.stack.Push(number)
+			evaluator.stack.Push(value)
+		case models.StartExpressionCommand:
+			evaluator.expressionCommands = []models.Command{}
+			evaluator.expressionDepth = 1
+		case models.EndExpressionCommand:
+			return fmt.Errorf(
+				"missed start of the expression for command %+v with number #%d",
+				command,
+				commandIndex,
+			)
 		}
 	}
 
+	// the result of the last command is checked only here
+	if err := evaluator.checkStackDepth(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // Finalize ...
-func (evaluator Evaluator) Finalize() (float64, error) {
-	number, ok := evaluator.stack.Pop()
+func (evaluator Evaluator) Finalize() (models.Value, error) {
+	value, ok := evaluator.stack.Pop()
 	if !ok {
-		return 0, errors.New("number stack is empty")
+		return models.Value{}, errors.New("value stack is empty")
+	}
+
+	return value, nil
+}
+
+// it checks the context and the limits before the next command
+func (evaluator *Evaluator) checkResources(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	return number, nil
+	if evaluator.limits.MaxCommandCount != 0 {
+		commandCount := atomic.AddInt64(evaluator.commandCount, 1)
+		if commandCount > int64(evaluator.limits.MaxCommandCount) {
+			return CommandCountError{
+				MaxCommandCount: evaluator.limits.MaxCommandCount,
+			}
+		}
+	}
+
+	return evaluator.checkStackDepth()
 }
 
-func reverseArguments(arguments []float64) {
+func (evaluator *Evaluator) checkStackDepth() error {
+	maxStackDepth := evaluator.limits.MaxStackDepth
+	if maxStackDepth != 0 && len(evaluator.stack) > maxStackDepth {
+		return StackDepthError{MaxStackDepth: maxStackDepth}
+	}
+
+	return nil
+}
+
+// it pushes the expression as a value when its last command is collected
+func (evaluator *Evaluator) collectExpressionCommand(
+	ctx context.Context,
+	command models.Command,
+	scope *variableScope,
+	functions models.FunctionProvider,
+) {
+	switch command.Kind {
+	case models.StartExpressionCommand:
+		evaluator.expressionDepth++
+	case models.EndExpressionCommand:
+		evaluator.expressionDepth--
+	}
+	if evaluator.expressionDepth != 0 {
+		evaluator.expressionCommands =
+			append(evaluator.expressionCommands, command)
+		return
+	}
+
+	limits, commandCount, resolver :=
+		evaluator.limits, evaluator.commandCount, evaluator.resolver
+	evaluator.stack.Push(models.NewExpression(
+		evaluator.expressionCommands,
+		func(
+			commands []models.Command,
+			bindings models.VariableGroup,
+		) (models.Value, error) {
+			localEvaluator := Evaluator{
+				limits:       limits,
+				commandCount: commandCount,
+				resolver:     resolver,
+			}
+			err := localEvaluator.evaluate(
+				ctx,
+				commands,
+				scope.bind(bindings),
+				functions,
+			)
+			if err != nil {
+				return models.Value{}, err
+			}
+
+			return localEvaluator.Finalize()
+		},
+	))
+	evaluator.expressionCommands = nil
+}
+
+func isOperator(name string) bool {
+	symbol, size := utf8.DecodeRuneInString(name)
+	if size != len(name) {
+		return false
+	}
+
+	kind, err := models.ParseTokenKind(symbol)
+	return err == nil && kind.IsOperator()
+}
+
+func reverseArguments(arguments []models.Value) {
 	arity := len(arguments)
 	for i := 0; i < arity/2; i++ {
 		arguments[arity-i-1], arguments[i] = arguments[i], arguments[arity-i-1]
 	}
 }
+
+func parseNumber(text string) (models.Value, error) {
+	if strings.HasSuffix(text, "i") {
+		number, err := strconv.ParseComplex(text, 128)
+		if err != nil {
+			return models.Value{}, err
+		}
+
+		return models.NewComplex(number), nil
+	}
+
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(number), nil
+}
+
+func lookupUnit(name string) (models.Value, bool) {
+	unit, ok := units.BuiltInUnits.Lookup(name)
+	if !ok {
+		return models.Value{}, false
+	}
+
+	return models.NewQuantity(unit.Factor, unit.Dimension), true
+}
@@ -1,6 +1,8 @@
 package evaluator
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"testing/iotest"
 
@@ -16,10 +18,10 @@ func TestEvaluator(test *testing.T) {
 	}
 
 	testsCases := []struct {
-		name       string
-		args       args
-		wantNumber float64
-		wantErr    string
+		name      string
+		args      args
+		wantValue models.Value
+		wantErr   string
 	}{
 		{
 			name: "without commands",
@@ -28,8 +30,8 @@ func TestEvaluator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			wantNumber: 0,
-			wantErr:    "number stack is empty",
+			wantValue: models.Value{},
+			wantErr:   "value stack is empty",
 		},
 		{
 			name: "with the push number command (success)",
@@ -40,8 +42,8 @@ func TestEvaluator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			wantNumber: 2.3,
-			wantErr:    "",
+			wantValue: models.NewNumber(2.3),
+			wantErr:   "",
 		},
 		{
 			name: "with the push number command (error)",
@@ -52,22 +54,100 @@ func TestEvaluator(test *testing.T) {
 				variables: nil,
 				functions: nil,
 			},
-			wantNumber: 0,
-			wantErr: "incorrect number for command {Kind:0 Operand:incorrect} " +
-				"with number #0: strconv.ParseFloat: parsing \"incorrect\": " +
+			wantValue: models.Value{},
+			wantErr: "incorrect number for command " +
+				"{Kind:0 Operand:incorrect ArgumentCount:0} with number #0: strconv.ParseFloat: parsing \"incorrect\": " +
 				"invalid syntax",
 		},
+		{
+			name: "with the push number command (success with an imaginary number)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2.5e1i"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.NewComplex(25i),
+			wantErr:   "",
+		},
+		{
+			name: "with the push number command (error with an imaginary number)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "incorrecti"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "incorrect number for command " +
+				"{Kind:0 Operand:incorrecti ArgumentCount:0} with number #0: strconv.ParseComplex: parsing \"incorrecti\": " +
+				"invalid syntax",
+		},
+		{
+			name: "with the push string command",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushStringCommand, Operand: "test"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.NewString("test"),
+			wantErr:   "",
+		},
 		{
 			name: "with the push variable command (success)",
 			args: args{
 				commands: []models.Command{
 					{Kind: models.PushVariableCommand, Operand: "test"},
 				},
-				variables: models.VariableGroup{"test": 2.3},
+				variables: models.VariableGroup{"test": models.NewNumber(2.3)},
+				functions: nil,
+			},
+			wantValue: models.NewNumber(2.3),
+			wantErr:   "",
+		},
+		{
+			name: "with the push unit command (success with a unit)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushUnitCommand, Operand: "km"},
+				},
+				variables: models.VariableGroup{"test": models.NewNumber(2.3)},
+				functions: nil,
+			},
+			wantValue: models.NewQuantity(
+				1000,
+				models.NewBaseDimension(models.LengthDimension),
+			),
+			wantErr: "",
+		},
+		{
+			name: "with the push unit command (success with shadowing of a unit)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushUnitCommand, Operand: "km"},
+				},
+				variables: models.VariableGroup{"km": models.NewNumber(2.3)},
 				functions: nil,
 			},
-			wantNumber: 2.3,
-			wantErr:    "",
+			wantValue: models.NewNumber(2.3),
+			wantErr:   "",
+		},
+		{
+			name: "with the push variable command (error with a unit)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "km"},
+				},
+				variables: models.VariableGroup{"test": models.NewNumber(2.3)},
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "unknown variable in command " +
+				"{Kind:1 Operand:km ArgumentCount:0} with number #0",
 		},
 		{
 			name: "with the push variable command (error)",
@@ -75,12 +155,125 @@ func TestEvaluator(test *testing.T) {
 				commands: []models.Command{
 					{Kind: models.PushVariableCommand, Operand: "unknown"},
 				},
-				variables: models.VariableGroup{"test": 2.3},
+				variables: models.VariableGroup{"test": models.NewNumber(2.3)},
 				functions: nil,
 			},
-			wantNumber: 0,
-			wantErr: "unknown variable in command {Kind:1 Operand:unknown} " +
-				"with number #0",
+			wantValue: models.Value{},
+			wantErr: "unknown variable in command " +
+				"{Kind:1 Operand:unknown ArgumentCount:0} with number #0",
+		},
+		{
+			name: "with the index command (success)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "test"},
+					{Kind: models.PushNumberCommand, Operand: "1"},
+					{Kind: models.IndexCommand, Operand: ""},
+				},
+				variables: models.VariableGroup{
+					"test": models.NewArray(models.NewNumber(2), models.NewNumber(3)),
+				},
+				functions: nil,
+			},
+			wantValue: models.NewNumber(3),
+			wantErr:   "",
+		},
+		{
+			name: "with the index command (error with lack of the index)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.IndexCommand, Operand: ""},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "value stack is empty for the index in command " +
+				"{Kind:4 Operand: ArgumentCount:0} with number #0",
+		},
+		{
+			name: "with the index command (error with lack of the array)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "1"},
+					{Kind: models.IndexCommand, Operand: ""},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "value stack is empty for the array in command " +
+				"{Kind:4 Operand: ArgumentCount:0} with number #1",
+		},
+		{
+			name: "with the index command (error with getting of the element)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "1"},
+					{Kind: models.IndexCommand, Operand: ""},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to get the element in command " +
+				"{Kind:4 Operand: ArgumentCount:0} with number #2: " +
+				"unable to index the value of type number",
+		},
+		{
+			name: "with the make array command (success)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushStringCommand, Operand: "test"},
+					{Kind: models.MakeArrayCommand, Operand: "2"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.NewArray(models.NewNumber(2), models.NewString("test")),
+			wantErr:   "",
+		},
+		{
+			name: "with the make array command (success with an empty array)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.MakeArrayCommand, Operand: "0"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.NewArray(),
+			wantErr:   "",
+		},
+		{
+			name: "with the make array command (error with an element count)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.MakeArrayCommand, Operand: "-1"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "incorrect element count for command " +
+				"{Kind:5 Operand:-1 ArgumentCount:0} with number #0: " +
+				"strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name: "with the make array command (error with lack of elements)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.MakeArrayCommand, Operand: "2"},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "value stack is empty for element #0 in command " +
+				"{Kind:5 Operand:2 ArgumentCount:0} with number #1",
 		},
 		{
 			name: "with the call function command (success)",
@@ -88,7 +281,7 @@ func TestEvaluator(test *testing.T) {
 				commands: []models.Command{
 					{Kind: models.PushNumberCommand, Operand: "2"},
 					{Kind: models.PushNumberCommand, Operand: "3"},
-					{Kind: models.CallFunctionCommand, Operand: "sub"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
 				},
 				variables: nil,
 				functions: models.FunctionGroup{
@@ -100,8 +293,99 @@ func TestEvaluator(test *testing.T) {
 					},
 				},
 			},
-			wantNumber: -1,
-			wantErr:    "",
+			wantValue: models.NewNumber(-1),
+			wantErr:   "",
+		},
+		{
+			name: "with the call function command (optional arguments)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{
+						Kind:          models.CallFunctionCommand,
+						Operand:       "sub",
+						ArgumentCount: 2,
+					},
+				},
+				variables: nil,
+				functions: models.FunctionGroup{
+					"sub": {
+						Arity:    1,
+						MaxArity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							if len(arguments) == 1 {
+								return -arguments[0], nil
+							}
+
+							return arguments[0] - arguments[1], nil
+						},
+					},
+				},
+			},
+			wantValue: models.NewNumber(-1),
+			wantErr:   "",
+		},
+		{
+			name: "with the call function command (variadic function)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.PushNumberCommand, Operand: "4"},
+					{
+						Kind:          models.CallFunctionCommand,
+						Operand:       "sum",
+						ArgumentCount: 3,
+					},
+				},
+				variables: nil,
+				functions: models.FunctionGroup{
+					"sum": {
+						Arity:    1,
+						Variadic: true,
+						ValueHandler: func(arguments []models.Value) (models.Value, error) {
+							sum := 0.0
+							for _, argument := range arguments {
+								sum += argument.Number
+							}
+
+							return models.NewNumber(sum), nil
+						},
+					},
+				},
+			},
+			wantValue: models.NewNumber(9),
+			wantErr:   "",
+		},
+		{
+			name: "with the call function command " +
+				"(error with an incorrect argument count)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{
+						Kind:          models.CallFunctionCommand,
+						Operand:       "sub",
+						ArgumentCount: 3,
+					},
+				},
+				variables: nil,
+				functions: models.FunctionGroup{
+					"sub": {
+						Arity:    1,
+						MaxArity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] - arguments[1], nil
+						},
+					},
+				},
+			},
+			wantValue: models.Value{},
+			wantErr: "incorrect argument count for command " +
+				"{Kind:2 Operand:sub ArgumentCount:3} with number #2: " +
+				"3 is given, but from 1 to 2 are expected",
 		},
 		{
 			name: "with the call function command (error with an unknown function)",
@@ -121,16 +405,16 @@ func TestEvaluator(test *testing.T) {
 					},
 				},
 			},
-			wantNumber: 0,
-			wantErr: "unknown function in command {Kind:2 Operand:unknown} " +
-				"with number #2",
+			wantValue: models.Value{},
+			wantErr: "unknown function in command " +
+				"{Kind:2 Operand:unknown ArgumentCount:0} with number #2",
 		},
 		{
 			name: "with the call function command (error with lack of arguments)",
 			args: args{
 				commands: []models.Command{
 					{Kind: models.PushNumberCommand, Operand: "2"},
-					{Kind: models.CallFunctionCommand, Operand: "sub"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
 				},
 				variables: nil,
 				functions: models.FunctionGroup{
@@ -142,9 +426,103 @@ func TestEvaluator(test *testing.T) {
 					},
 				},
 			},
-			wantNumber: 0,
-			wantErr: "number stack is empty for argument #1 in command " +
-				"{Kind:2 Operand:sub} with number #1",
+			wantValue: models.Value{},
+			wantErr: "value stack is empty for argument #1 in command " +
+				"{Kind:2 Operand:sub ArgumentCount:2} with number #1",
+		},
+		{
+			name: "with the call function command (error with the argument count)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.PushNumberCommand, Operand: "4"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 3},
+				},
+				variables: nil,
+				functions: models.FunctionGroup{
+					"sub": {
+						Arity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] - arguments[1], nil
+						},
+					},
+				},
+			},
+			wantValue: models.Value{},
+			wantErr: "incorrect argument count for command " +
+				"{Kind:2 Operand:sub ArgumentCount:3} with number #3: " +
+				"3 is given, but 2 are expected",
+		},
+		{
+			name: "with the call function command (success with the operator)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.CallFunctionCommand, Operand: "-"},
+				},
+				variables: nil,
+				functions: models.FunctionGroup{
+					"-": {
+						Arity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] - arguments[1], nil
+						},
+					},
+				},
+			},
+			wantValue: models.NewNumber(-1),
+			wantErr:   "",
+		},
+		{
+			name: "with the expression commands (success)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.StartExpressionCommand},
+					{Kind: models.StartExpressionCommand},
+					{Kind: models.PushVariableCommand, Operand: "x"},
+					{Kind: models.EndExpressionCommand},
+					{Kind: models.CallFunctionCommand, Operand: "apply", ArgumentCount: 1},
+					{Kind: models.EndExpressionCommand},
+					{Kind: models.CallFunctionCommand, Operand: "apply", ArgumentCount: 1},
+				},
+				variables: models.VariableGroup{"x": models.NewNumber(5)},
+				functions: models.FunctionGroup{
+					"apply": {
+						Arity:             1,
+						LazyArgumentCount: 1,
+						ValueHandler: func(
+							arguments []models.Value,
+						) (models.Value, error) {
+							value, err := arguments[0].Expression.Evaluate(
+								models.VariableGroup{"x": models.NewNumber(3)},
+							)
+							if err != nil {
+								return models.Value{}, err
+							}
+
+							return models.NewNumber(value.Number * 10), nil
+						},
+					},
+				},
+			},
+			wantValue: models.NewNumber(300),
+			wantErr:   "",
+		},
+		{
+			name: "with the expression commands (error)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.EndExpressionCommand},
+				},
+				variables: nil,
+				functions: nil,
+			},
+			wantValue: models.Value{},
+			wantErr: "missed start of the expression for command " +
+				"{Kind:7 Operand: ArgumentCount:0} with number #1",
 		},
 		{
 			name: "with the call function command (error with the function call)",
@@ -152,7 +530,7 @@ func TestEvaluator(test *testing.T) {
 				commands: []models.Command{
 					{Kind: models.PushNumberCommand, Operand: "2"},
 					{Kind: models.PushNumberCommand, Operand: "3"},
-					{Kind: models.CallFunctionCommand, Operand: "sub"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
 				},
 				variables: nil,
 				functions: models.FunctionGroup{
@@ -164,14 +542,38 @@ func TestEvaluator(test *testing.T) {
 					},
 				},
 			},
-			wantNumber: 0,
+			wantValue: models.Value{},
+			wantErr: "unable to call the function from command " +
+				"{Kind:2 Operand:sub ArgumentCount:2} with number #2: " +
+				iotest.ErrTimeout.Error(),
+		},
+		{
+			name: "with the call function command (error with an argument kind)",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushStringCommand, Operand: "test"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+				},
+				variables: nil,
+				functions: models.FunctionGroup{
+					"sub": {
+						Arity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] - arguments[1], nil
+						},
+					},
+				},
+			},
+			wantValue: models.Value{},
 			wantErr: "unable to call the function from command " +
-				"{Kind:2 Operand:sub} with number #2: " + iotest.ErrTimeout.Error(),
+				"{Kind:2 Operand:sub ArgumentCount:2} with number #2: " +
+				"argument #1 has type string, but number is expected",
 		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
-			gotNumber := 0.0
+			gotValue := models.Value{}
 
 			evaluator := Evaluator{}
 			gotErr := evaluator.Evaluate(
@@ -180,10 +582,10 @@ func TestEvaluator(test *testing.T) {
 				testCase.args.functions,
 			)
 			if gotErr == nil {
-				gotNumber, gotErr = evaluator.Finalize()
+				gotValue, gotErr = evaluator.Finalize()
 			}
 
-			assert.Equal(test, testCase.wantNumber, gotNumber)
+			assert.Equal(test, testCase.wantValue, gotValue)
 			if testCase.wantErr == "" {
 				assert.NoError(test, gotErr)
 			} else {
@@ -201,9 +603,9 @@ func TestEvaluator_withSequentialCalls(test *testing.T) {
 	}
 
 	testsCases := []struct {
-		name       string
-		args       args
-		wantNumber float64
+		name      string
+		args      args
+		wantValue models.Value
 	}{
 		{
 			name: "with the call function command",
@@ -214,7 +616,7 @@ func TestEvaluator_withSequentialCalls(test *testing.T) {
 						{Kind: models.PushNumberCommand, Operand: "3"},
 					},
 					{
-						{Kind: models.CallFunctionCommand, Operand: "sub"},
+						{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
 					},
 				},
 				variables: nil,
@@ -227,12 +629,12 @@ func TestEvaluator_withSequentialCalls(test *testing.T) {
 					},
 				},
 			},
-			wantNumber: -1,
+			wantValue: models.NewNumber(-1),
 		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
-			gotNumber, gotErr := 0.0, error(nil)
+			gotValue, gotErr := models.Value{}, error(nil)
 
 			evaluator := Evaluator{}
 			for _, commandGroup := range testCase.args.commandGroups {
@@ -246,11 +648,357 @@ func TestEvaluator_withSequentialCalls(test *testing.T) {
 				}
 			}
 			if gotErr == nil {
-				gotNumber, gotErr = evaluator.Finalize()
+				gotValue, gotErr = evaluator.Finalize()
 			}
 
-			assert.Equal(test, testCase.wantNumber, gotNumber)
+			assert.Equal(test, testCase.wantValue, gotValue)
 			assert.NoError(test, gotErr)
 		})
 	}
 }
+
+func TestEvaluator_withLimits(test *testing.T) {
+	type args struct {
+		limits    Limits
+		commands  []models.Command
+		variables models.VariableGroup
+		functions models.FunctionGroup
+	}
+
+	functions := models.FunctionGroup{
+		"sub": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] - arguments[1], nil
+			},
+		},
+		"twice": {
+			Arity:             1,
+			LazyArgumentCount: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				if _, err := arguments[0].Evaluate(); err != nil {
+					return models.Value{}, err
+				}
+
+				return arguments[0].Evaluate()
+			},
+		},
+	}
+
+	testsCases := []struct {
+		name      string
+		args      args
+		wantValue models.Value
+		wantErr   string
+	}{
+		{
+			name: "success",
+			args: args{
+				limits: Limits{MaxCommandCount: 3, MaxStackDepth: 2},
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+				},
+				variables: nil,
+				functions: functions,
+			},
+			wantValue: models.NewNumber(-1),
+			wantErr:   "",
+		},
+		{
+			name: "success with the expression",
+			args: args{
+				limits: Limits{MaxCommandCount: 12, MaxStackDepth: 2},
+				commands: []models.Command{
+					{Kind: models.StartExpressionCommand},
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+					{Kind: models.EndExpressionCommand},
+					{Kind: models.CallFunctionCommand, Operand: "twice", ArgumentCount: 1},
+				},
+				variables: nil,
+				functions: functions,
+			},
+			wantValue: models.NewNumber(-1),
+			wantErr:   "",
+		},
+		{
+			name: "error with the command count",
+			args: args{
+				limits: Limits{MaxCommandCount: 2},
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+				},
+				variables: nil,
+				functions: functions,
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to execute command " +
+				"{Kind:2 Operand:sub ArgumentCount:2} with number #2: " +
+				"command count exceeds the limit of 2",
+		},
+		{
+			name: "error with the command count in the expression",
+			args: args{
+				limits: Limits{MaxCommandCount: 10},
+				commands: []models.Command{
+					{Kind: models.StartExpressionCommand},
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+					{Kind: models.EndExpressionCommand},
+					{Kind: models.CallFunctionCommand, Operand: "twice", ArgumentCount: 1},
+				},
+				variables: nil,
+				functions: functions,
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to call the function from command " +
+				"{Kind:2 Operand:twice ArgumentCount:1} with number #5: " +
+				"unable to execute command " +
+				"{Kind:0 Operand:3 ArgumentCount:0} with number #1: " +
+				"command count exceeds the limit of 10",
+		},
+		{
+			name: "error with the stack depth",
+			args: args{
+				limits: Limits{MaxStackDepth: 1},
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+				},
+				variables: nil,
+				functions: functions,
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to execute command " +
+				"{Kind:2 Operand:sub ArgumentCount:2} with number #2: " +
+				"stack depth exceeds the limit of 1",
+		},
+		{
+			name: "error with the stack depth after the last command",
+			args: args{
+				limits: Limits{MaxStackDepth: 1},
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+				},
+				variables: nil,
+				functions: functions,
+			},
+			wantValue: models.Value{},
+			wantErr:   "stack depth exceeds the limit of 1",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue := models.Value{}
+
+			evaluator := NewEvaluator(testCase.args.limits)
+			gotErr := evaluator.Evaluate(
+				testCase.args.commands,
+				testCase.args.variables,
+				testCase.args.functions,
+			)
+			if gotErr == nil {
+				gotValue, gotErr = evaluator.Finalize()
+			}
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluator_EvaluateContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	functions := models.FunctionGroup{
+		"cancel": {
+			Arity:             1,
+			LazyArgumentCount: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				cancel()
+				return arguments[0].Evaluate()
+			},
+		},
+	}
+
+	var evaluator Evaluator
+	gotErr := evaluator.EvaluateContext(
+		ctx,
+		[]models.Command{
+			{Kind: models.StartExpressionCommand},
+			{Kind: models.PushNumberCommand, Operand: "2"},
+			{Kind: models.EndExpressionCommand},
+			{Kind: models.CallFunctionCommand, Operand: "cancel", ArgumentCount: 1},
+			{Kind: models.PushNumberCommand, Operand: "3"},
+		},
+		nil,
+		functions,
+	)
+
+	assert.EqualError(
+		test,
+		gotErr,
+		"unable to call the function from command "+
+			"{Kind:2 Operand:cancel ArgumentCount:1} with number #3: "+
+			"unable to execute command "+
+			"{Kind:0 Operand:2 ArgumentCount:0} with number #0: "+
+			"context canceled",
+	)
+	assert.True(test, errors.Is(gotErr, context.Canceled))
+}
+
+func TestEvaluator_WithResolver(test *testing.T) {
+	type args struct {
+		commands  []models.Command
+		variables models.VariableGroup
+	}
+
+	functions := models.FunctionGroup{
+		"sub": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] - arguments[1], nil
+			},
+		},
+		"twice": {
+			Arity:             1,
+			LazyArgumentCount: 1,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				if _, err := arguments[0].Evaluate(); err != nil {
+					return models.Value{}, err
+				}
+
+				return arguments[0].Evaluate()
+			},
+		},
+	}
+
+	testsCases := []struct {
+		name          string
+		args          args
+		wantValue     models.Value
+		wantResolving map[string]int
+		wantErr       string
+	}{
+		{
+			name: "success with the cache",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "x"},
+					{Kind: models.PushVariableCommand, Operand: "y"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+					{Kind: models.PushVariableCommand, Operand: "y"},
+					{Kind: models.CallFunctionCommand, Operand: "sub", ArgumentCount: 2},
+				},
+				variables: models.VariableGroup{"x": models.NewNumber(2)},
+			},
+			wantValue:     models.NewNumber(-4),
+			wantResolving: map[string]int{"y": 1},
+			wantErr:       "",
+		},
+		{
+			name: "success with the cache in the expression",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.StartExpressionCommand},
+					{Kind: models.PushVariableCommand, Operand: "y"},
+					{Kind: models.EndExpressionCommand},
+					{Kind: models.CallFunctionCommand, Operand: "twice", ArgumentCount: 1},
+				},
+				variables: nil,
+			},
+			wantValue:     models.NewNumber(3),
+			wantResolving: map[string]int{"y": 1},
+			wantErr:       "",
+		},
+		{
+			name: "success with the unit",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushUnitCommand, Operand: "km"},
+				},
+				variables: nil,
+			},
+			wantValue:     models.NewQuantity(1000, models.Dimension{1}),
+			wantResolving: map[string]int{"km": 1},
+			wantErr:       "",
+		},
+		{
+			name: "error with the unknown variable",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "unknown"},
+				},
+				variables: nil,
+			},
+			wantValue:     models.Value{},
+			wantResolving: map[string]int{"unknown": 1},
+			wantErr: "unknown variable in command " +
+				"{Kind:1 Operand:unknown ArgumentCount:0} with number #0",
+		},
+		{
+			name: "error with the resolver",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "y"},
+					{Kind: models.PushVariableCommand, Operand: "broken"},
+				},
+				variables: nil,
+			},
+			wantValue:     models.Value{},
+			wantResolving: map[string]int{"y": 1, "broken": 1},
+			wantErr: "unable to resolve variable broken in command " +
+				"{Kind:1 Operand:broken ArgumentCount:0} with number #1: " +
+				"connection refused",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue := models.Value{}
+			gotResolving := map[string]int{}
+			resolver := VariableResolverFunc(func(name string) (float64, bool, error) {
+				gotResolving[name]++
+
+				switch name {
+				case "y":
+					return 3, true, nil
+				case "broken":
+					return 0, false, errors.New("connection refused")
+				default:
+					return 0, false, nil
+				}
+			})
+
+			evaluator := NewEvaluator(Limits{}).WithResolver(resolver)
+			gotErr := evaluator.Evaluate(
+				testCase.args.commands,
+				testCase.args.variables,
+				functions,
+			)
+			if gotErr == nil {
+				gotValue, gotErr = evaluator.Finalize()
+			}
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			assert.Equal(test, testCase.wantResolving, gotResolving)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package evaluator
+
+import "fmt"
+
+// Limits restrict resources of the evaluation; zero values mean no limits.
+type Limits struct {
+	// it includes commands of the evaluated expressions,
+	// e.g. of all the terms of the sum function
+	MaxCommandCount int
+	// it's checked for the value stack of each evaluated expression separately
+	MaxStackDepth int
+}
+
+// CommandCountError is returned when the evaluation executes
+// more commands than the limit allows.
+type CommandCountError struct {
+	MaxCommandCount int
+}
+
+// Error ...
+func (err CommandCountError) Error() string {
+	return fmt.Sprintf("command count exceeds the limit of %d", err.MaxCommandCount)
+}
+
+// StackDepthError is returned when the value stack grows
+// deeper than the limit allows.
+type StackDepthError struct {
+	MaxStackDepth int
+}
+
+// Error ...
+func (err StackDepthError) Error() string {
+	return fmt.Sprintf("stack depth exceeds the limit of %d", err.MaxStackDepth)
+}
@@ -0,0 +1,61 @@
+package evaluator
+
+import "sync"
+
+// VariableResolver provides variables missed in the variable group,
+// e.g. ones kept in a database; it's consulted before the units.
+// It may be called from several goroutines if expressions are evaluated
+// concurrently, so it should be safe for concurrent use.
+type VariableResolver interface {
+	// ok is false if there's no such variable
+	Resolve(name string) (value float64, ok bool, err error)
+}
+
+// VariableResolverFunc allows to use a function as a VariableResolver.
+type VariableResolverFunc func(name string) (value float64, ok bool, err error)
+
+// Resolve ...
+func (resolver VariableResolverFunc) Resolve(name string) (
+	value float64,
+	ok bool,
+	err error,
+) {
+	return resolver(name)
+}
+
+type resolvedVariable struct {
+	value float64
+	ok    bool
+}
+
+// it caches results of the resolver, including missed variables but not errors;
+// it's shared with evaluators of the expressions, so it has a lock
+type cachedResolver struct {
+	resolver VariableResolver
+	cache    map[string]resolvedVariable
+	lock     sync.Mutex
+}
+
+func newCachedResolver(resolver VariableResolver) *cachedResolver {
+	return &cachedResolver{
+		resolver: resolver,
+		cache:    map[string]resolvedVariable{},
+	}
+}
+
+func (resolver *cachedResolver) Resolve(name string) (float64, bool, error) {
+	resolver.lock.Lock()
+	defer resolver.lock.Unlock()
+
+	if variable, ok := resolver.cache[name]; ok {
+		return variable.value, variable.ok, nil
+	}
+
+	value, ok, err := resolver.resolver.Resolve(name)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resolver.cache[name] = resolvedVariable{value: value, ok: ok}
+	return value, ok, nil
+}
@@ -0,0 +1,29 @@
+package evaluator
+
+import "github.com/irenicaa/go-calculator/v2/models"
+
+// it looks up the bindings of an unevaluated expression before the variables
+// of the enclosing scopes, so the variables aren't copied for each evaluation
+// of the expression, e.g. for each term of a series
+type variableScope struct {
+	variables models.VariableGroup
+	parent    *variableScope
+}
+
+func (scope *variableScope) lookup(name string) (models.Value, bool) {
+	for ; scope != nil; scope = scope.parent {
+		if value, ok := scope.variables[name]; ok {
+			return value, true
+		}
+	}
+
+	return models.Value{}, false
+}
+
+func (scope *variableScope) bind(bindings models.VariableGroup) *variableScope {
+	if len(bindings) == 0 {
+		return scope
+	}
+
+	return &variableScope{variables: bindings, parent: scope}
+}
@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariableScope_lookup(test *testing.T) {
+	variables := models.VariableGroup{
+		"x": models.NewNumber(2),
+		"y": models.NewNumber(3),
+	}
+	scope := (&variableScope{variables: variables}).
+		bind(models.VariableGroup{"x": models.NewNumber(5)}).
+		bind(nil)
+
+	type args struct {
+		name string
+	}
+
+	testsCases := []struct {
+		name      string
+		args      args
+		wantValue models.Value
+		wantOk    bool
+	}{
+		{
+			name:      "binding",
+			args:      args{name: "x"},
+			wantValue: models.NewNumber(5),
+			wantOk:    true,
+		},
+		{
+			name:      "variable",
+			args:      args{name: "y"},
+			wantValue: models.NewNumber(3),
+			wantOk:    true,
+		},
+		{
+			name:      "unknown variable",
+			args:      args{name: "z"},
+			wantValue: models.Value{},
+			wantOk:    false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotOk := scope.lookup(testCase.args.name)
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+
+	// the variables aren't changed by the bindings
+	assert.Equal(test, models.VariableGroup{
+		"x": models.NewNumber(2),
+		"y": models.NewNumber(3),
+	}, variables)
+}
@@ -0,0 +1,356 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// FinanceFunctions is intended to be merged into BuiltInFunctions;
+// its functions follow the semantics of the same functions of Excel,
+// i.e. cash paid out is negative, cash received is positive, and
+// the optional type argument is 0 for payments at the end of periods
+// and 1 for payments at their beginning
+var FinanceFunctions = models.FunctionGroup{
+	"pmt":      {Arity: 3, MaxArity: 5, Handler: payment},
+	"ipmt":     {Arity: 4, MaxArity: 6, Handler: interestPayment},
+	"ppmt":     {Arity: 4, MaxArity: 6, Handler: principalPayment},
+	"fv":       {Arity: 3, MaxArity: 5, Handler: futureValue},
+	"pv":       {Arity: 3, MaxArity: 5, Handler: presentValue},
+	"nper":     {Arity: 3, MaxArity: 5, Handler: periodCount},
+	"rate":     {Arity: 3, MaxArity: 6, Handler: interestRate},
+	"npv":      {Arity: 2, Variadic: true, ValueHandler: netPresentValue},
+	"irr":      {Arity: 1, MaxArity: 2, ValueHandler: internalRateOfReturn},
+	"compound": {Arity: 3, MaxArity: 4, Handler: compoundInterest},
+}
+
+// annuity contains the common arguments of the annuity functions
+type annuity struct {
+	rate           float64
+	periodCount    float64
+	payment        float64
+	presentValue   float64
+	futureValue    float64
+	isBeginningDue bool
+}
+
+// it parses the optional arguments starting from the specified one:
+// the future or present value and the type
+func parseOptionalAnnuityArguments(
+	arguments []float64,
+	firstIndex int,
+) (value float64, isBeginningDue bool, err error) {
+	if len(arguments) > firstIndex {
+		value = arguments[firstIndex]
+	}
+	if len(arguments) > firstIndex+1 {
+		switch arguments[firstIndex+1] {
+		case 0:
+		case 1:
+			isBeginningDue = true
+		default:
+			return 0, false, fmt.Errorf("argument #%d should be 0 or 1", firstIndex+1)
+		}
+	}
+
+	return value, isBeginningDue, nil
+}
+
+// the factor of payments for the beginning of periods
+func (annuity annuity) dueFactor() float64 {
+	if annuity.isBeginningDue {
+		return 1 + annuity.rate
+	}
+
+	return 1
+}
+
+// it's zero for the correct combination of the values
+func (annuity annuity) balance() float64 {
+	if annuity.rate == 0 {
+		return annuity.presentValue + annuity.payment*annuity.periodCount +
+			annuity.futureValue
+	}
+
+	// the growth minus one is calculated without the cancellation,
+	// so the balance stays smooth near the zero rate,
+	// where the solver of the rate converges
+	logGrowth := annuity.periodCount * math.Log1p(annuity.rate)
+	return annuity.presentValue*math.Exp(logGrowth) +
+		annuity.payment*annuity.dueFactor()*math.Expm1(logGrowth)/annuity.rate +
+		annuity.futureValue
+}
+
+func (annuity annuity) calculatePayment() float64 {
+	if annuity.rate == 0 {
+		return -(annuity.presentValue + annuity.futureValue) / annuity.periodCount
+	}
+
+	growth := math.Pow(1+annuity.rate, annuity.periodCount)
+	return -annuity.rate * (annuity.futureValue + annuity.presentValue*growth) /
+		(annuity.dueFactor() * (growth - 1))
+}
+
+func (annuity annuity) calculateFutureValue() float64 {
+	annuity.futureValue = 0
+	return -annuity.balance()
+}
+
+// pmt(rate, nper, pv[, fv[, type]])
+func payment(arguments []float64) (float64, error) {
+	futureValue, isBeginningDue, err := parseOptionalAnnuityArguments(arguments, 3)
+	if err != nil {
+		return 0, err
+	}
+	// Excel returns #NUM! for these values;
+	// the negated comparison also catches NaN
+	if !(arguments[0] > -1) {
+		return 0, errors.New("argument #0 should be greater than -1")
+	}
+	if arguments[1] == 0 {
+		return 0, errors.New("argument #1 should be nonzero")
+	}
+
+	return annuity{
+		rate:           arguments[0],
+		periodCount:    arguments[1],
+		presentValue:   arguments[2],
+		futureValue:    futureValue,
+		isBeginningDue: isBeginningDue,
+	}.calculatePayment(), nil
+}
+
+// ipmt(rate, per, nper, pv[, fv[, type]])
+func interestPayment(arguments []float64) (float64, error) {
+	futureValue, isBeginningDue, err := parseOptionalAnnuityArguments(arguments, 4)
+	if err != nil {
+		return 0, err
+	}
+
+	period := arguments[1]
+	if !(period >= 1 && period <= arguments[2]) {
+		return 0, errors.New("argument #1 should be in the range [1, argument #2]")
+	}
+
+	loan := annuity{
+		rate:           arguments[0],
+		periodCount:    arguments[2],
+		presentValue:   arguments[3],
+		futureValue:    futureValue,
+		isBeginningDue: isBeginningDue,
+	}
+	if isBeginningDue && period == 1 {
+		return 0, nil
+	}
+
+	// the interest is charged on the balance after the previous period
+	previousBalance := annuity{
+		rate:           loan.rate,
+		periodCount:    period - 1,
+		payment:        loan.calculatePayment(),
+		presentValue:   loan.presentValue,
+		isBeginningDue: isBeginningDue,
+	}.calculateFutureValue()
+
+	interest := previousBalance * loan.rate
+	if isBeginningDue {
+		interest /= 1 + loan.rate
+	}
+
+	return interest, nil
+}
+
+// ppmt(rate, per, nper, pv[, fv[, type]])
+func principalPayment(arguments []float64) (float64, error) {
+	interest, err := interestPayment(arguments)
+	if err != nil {
+		return 0, err
+	}
+
+	totalPayment, err := payment(append(arguments[:1:1], arguments[2:]...))
+	if err != nil {
+		return 0, err
+	}
+
+	return totalPayment - interest, nil
+}
+
+// fv(rate, nper, pmt[, pv[, type]])
+func futureValue(arguments []float64) (float64, error) {
+	presentValue, isBeginningDue, err := parseOptionalAnnuityArguments(arguments, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	return annuity{
+		rate:           arguments[0],
+		periodCount:    arguments[1],
+		payment:        arguments[2],
+		presentValue:   presentValue,
+		isBeginningDue: isBeginningDue,
+	}.calculateFutureValue(), nil
+}
+
+// pv(rate, nper, pmt[, fv[, type]])
+func presentValue(arguments []float64) (float64, error) {
+	futureValue, isBeginningDue, err := parseOptionalAnnuityArguments(arguments, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	loan := annuity{
+		rate:           arguments[0],
+		periodCount:    arguments[1],
+		payment:        arguments[2],
+		futureValue:    futureValue,
+		isBeginningDue: isBeginningDue,
+	}
+	if loan.rate == 0 {
+		return -loan.balance(), nil
+	}
+
+	// the balance is linear in the present value
+	return -loan.balance() / math.Pow(1+loan.rate, loan.periodCount), nil
+}
+
+// nper(rate, pmt, pv[, fv[, type]])
+func periodCount(arguments []float64) (float64, error) {
+	futureValue, isBeginningDue, err := parseOptionalAnnuityArguments(arguments, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	loan := annuity{
+		rate:           arguments[0],
+		payment:        arguments[1],
+		presentValue:   arguments[2],
+		futureValue:    futureValue,
+		isBeginningDue: isBeginningDue,
+	}
+	if loan.rate == 0 {
+		if loan.payment == 0 {
+			return 0, errors.New("payment should be nonzero for a zero rate")
+		}
+
+		return -(loan.presentValue + loan.futureValue) / loan.payment, nil
+	}
+
+	duePayment := loan.payment * loan.dueFactor()
+	ratio := (duePayment - loan.futureValue*loan.rate) /
+		(duePayment + loan.presentValue*loan.rate)
+	if !(ratio > 0) || loan.rate <= -1 {
+		return 0, errors.New("there is no period count for these values")
+	}
+
+	return math.Log(ratio) / math.Log(1+loan.rate), nil
+}
+
+// rate(nper, pmt, pv[, fv[, type[, guess]]])
+func interestRate(arguments []float64) (float64, error) {
+	futureValue, isBeginningDue, err := parseOptionalAnnuityArguments(arguments, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	guess := 0.1
+	if len(arguments) > 5 {
+		guess = arguments[5]
+	}
+
+	loan := annuity{
+		periodCount:    arguments[0],
+		payment:        arguments[1],
+		presentValue:   arguments[2],
+		futureValue:    futureValue,
+		isBeginningDue: isBeginningDue,
+	}
+	return solveNewton(func(rate float64) (float64, error) {
+		loan.rate = rate
+		return loan.balance(), nil
+	}, guess)
+}
+
+// npv(rate, value, ...)
+func netPresentValue(arguments []models.Value) (models.Value, error) {
+	err := models.CheckArgumentKinds(arguments, models.NumberValue)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	values, err := collectNumbers(arguments, 1, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	// the first value is discounted for one period
+	return models.NewNumber(discount(arguments[0].Number, values) /
+		(1 + arguments[0].Number)), nil
+}
+
+// irr(values[, guess])
+func internalRateOfReturn(arguments []models.Value) (models.Value, error) {
+	values, err := collectNumbers(arguments[:1], 0, 2)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	guess := 0.1
+	if len(arguments) > 1 {
+		if arguments[1].Kind != models.NumberValue {
+			return models.Value{}, models.TypeError{
+				ArgumentIndex: 1,
+				Kind:          arguments[1].Kind,
+				WantedKind:    models.NumberValue,
+			}
+		}
+
+		guess = arguments[1].Number
+	}
+
+	hasPositive, hasNegative := false, false
+	for _, value := range values {
+		hasPositive = hasPositive || value > 0
+		hasNegative = hasNegative || value < 0
+	}
+	if !hasPositive || !hasNegative {
+		return models.Value{}, errors.New(
+			"values should contain at least one positive and one negative number",
+		)
+	}
+
+	rate, err := solveNewton(func(rate float64) (float64, error) {
+		return discount(rate, values), nil
+	}, guess)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(rate), nil
+}
+
+// compound(pv, rate, nper[, count of compoundings per period])
+func compoundInterest(arguments []float64) (float64, error) {
+	compoundingCount := 1.0
+	if len(arguments) > 3 {
+		if !(arguments[3] > 0) {
+			return 0, errors.New("argument #3 should be positive")
+		}
+
+		compoundingCount = arguments[3]
+	}
+
+	principal, rate, periods := arguments[0], arguments[1], arguments[2]
+	return principal * math.Pow(1+rate/compoundingCount, periods*compoundingCount), nil
+}
+
+// it discounts the values to the moment of the first one
+func discount(rate float64, values []float64) float64 {
+	result := 0.0
+	for index, value := range values {
+		result += value / math.Pow(1+rate, float64(index))
+	}
+
+	return result
+}
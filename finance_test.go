@@ -0,0 +1,487 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinanceFunctions(test *testing.T) {
+	type args struct {
+		name      string
+		arguments []models.Value
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantResult float64
+		wantErr    string
+	}{
+		{
+			name: "pmt/success",
+			args: args{
+				name: "pmt",
+				arguments: []models.Value{
+					models.NewNumber(0.08 / 12),
+					models.NewNumber(10),
+					models.NewNumber(10000),
+				},
+			},
+			wantResult: -1037.032089,
+			wantErr:    "",
+		},
+		{
+			name: "pmt/success with the type",
+			args: args{
+				name: "pmt",
+				arguments: []models.Value{
+					models.NewNumber(0.08 / 12),
+					models.NewNumber(10),
+					models.NewNumber(10000),
+					models.NewNumber(0),
+					models.NewNumber(1),
+				},
+			},
+			wantResult: -1030.164327,
+			wantErr:    "",
+		},
+		{
+			name: "pmt/success with a zero rate",
+			args: args{
+				name: "pmt",
+				arguments: []models.Value{
+					models.NewNumber(0),
+					models.NewNumber(10),
+					models.NewNumber(1000),
+				},
+			},
+			wantResult: -100,
+			wantErr:    "",
+		},
+		{
+			name: "pmt/error with the rate",
+			args: args{
+				name: "pmt",
+				arguments: []models.Value{
+					models.NewNumber(-1),
+					models.NewNumber(10),
+					models.NewNumber(100),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "argument #0 should be greater than -1",
+		},
+		{
+			name: "pmt/error with a zero period count",
+			args: args{
+				name: "pmt",
+				arguments: []models.Value{
+					models.NewNumber(0.05),
+					models.NewNumber(0),
+					models.NewNumber(100),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "argument #1 should be nonzero",
+		},
+		{
+			name: "pmt/error with the type",
+			args: args{
+				name: "pmt",
+				arguments: []models.Value{
+					models.NewNumber(0.08 / 12),
+					models.NewNumber(10),
+					models.NewNumber(10000),
+					models.NewNumber(0),
+					models.NewNumber(2),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "argument #4 should be 0 or 1",
+		},
+		{
+			name: "ipmt/success",
+			args: args{
+				name: "ipmt",
+				arguments: []models.Value{
+					models.NewNumber(0.1 / 12),
+					models.NewNumber(3),
+					models.NewNumber(36),
+					models.NewNumber(8000),
+				},
+			},
+			wantResult: -63.46219,
+			wantErr:    "",
+		},
+		{
+			name: "ipmt/success with the type",
+			args: args{
+				name: "ipmt",
+				arguments: []models.Value{
+					models.NewNumber(0.1 / 12),
+					models.NewNumber(3),
+					models.NewNumber(36),
+					models.NewNumber(8000),
+					models.NewNumber(0),
+					models.NewNumber(1),
+				},
+			},
+			wantResult: -62.937709,
+			wantErr:    "",
+		},
+		{
+			name: "ipmt/success with the first period of the type",
+			args: args{
+				name: "ipmt",
+				arguments: []models.Value{
+					models.NewNumber(0.1 / 12),
+					models.NewNumber(1),
+					models.NewNumber(36),
+					models.NewNumber(8000),
+					models.NewNumber(0),
+					models.NewNumber(1),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "ipmt/error",
+			args: args{
+				name: "ipmt",
+				arguments: []models.Value{
+					models.NewNumber(0.1 / 12),
+					models.NewNumber(37),
+					models.NewNumber(36),
+					models.NewNumber(8000),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "argument #1 should be in the range [1, argument #2]",
+		},
+		{
+			name: "ppmt",
+			args: args{
+				name: "ppmt",
+				arguments: []models.Value{
+					models.NewNumber(0.1 / 12),
+					models.NewNumber(1),
+					models.NewNumber(24),
+					models.NewNumber(2000),
+				},
+			},
+			wantResult: -75.623186,
+			wantErr:    "",
+		},
+		{
+			name: "fv/success",
+			args: args{
+				name: "fv",
+				arguments: []models.Value{
+					models.NewNumber(0.06 / 12),
+					models.NewNumber(10),
+					models.NewNumber(-200),
+					models.NewNumber(-500),
+					models.NewNumber(1),
+				},
+			},
+			wantResult: 2581.403374,
+			wantErr:    "",
+		},
+		{
+			name: "fv/success with a zero rate",
+			args: args{
+				name: "fv",
+				arguments: []models.Value{
+					models.NewNumber(0),
+					models.NewNumber(10),
+					models.NewNumber(-200),
+					models.NewNumber(-500),
+				},
+			},
+			wantResult: 2500,
+			wantErr:    "",
+		},
+		{
+			name: "pv/success",
+			args: args{
+				name: "pv",
+				arguments: []models.Value{
+					models.NewNumber(0.08 / 12),
+					models.NewNumber(240),
+					models.NewNumber(500),
+				},
+			},
+			wantResult: -59777.145851,
+			wantErr:    "",
+		},
+		{
+			name: "pv/success with a zero rate",
+			args: args{
+				name: "pv",
+				arguments: []models.Value{
+					models.NewNumber(0),
+					models.NewNumber(10),
+					models.NewNumber(-200),
+					models.NewNumber(1000),
+				},
+			},
+			wantResult: 1000,
+			wantErr:    "",
+		},
+		{
+			name: "nper/success",
+			args: args{
+				name: "nper",
+				arguments: []models.Value{
+					models.NewNumber(0.12 / 12),
+					models.NewNumber(-100),
+					models.NewNumber(-1000),
+					models.NewNumber(10000),
+					models.NewNumber(1),
+				},
+			},
+			wantResult: 59.673866,
+			wantErr:    "",
+		},
+		{
+			name: "nper/success with a zero rate",
+			args: args{
+				name: "nper",
+				arguments: []models.Value{
+					models.NewNumber(0),
+					models.NewNumber(-100),
+					models.NewNumber(1000),
+				},
+			},
+			wantResult: 10,
+			wantErr:    "",
+		},
+		{
+			name: "nper/error",
+			args: args{
+				name: "nper",
+				arguments: []models.Value{
+					models.NewNumber(0.01),
+					models.NewNumber(-10),
+					models.NewNumber(10000),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "there is no period count for these values",
+		},
+		{
+			name: "rate/success",
+			args: args{
+				name: "rate",
+				arguments: []models.Value{
+					models.NewNumber(48),
+					models.NewNumber(-200),
+					models.NewNumber(8000),
+				},
+			},
+			wantResult: 0.007701,
+			wantErr:    "",
+		},
+		{
+			name: "rate/success with the guess",
+			args: args{
+				name: "rate",
+				arguments: []models.Value{
+					models.NewNumber(48),
+					models.NewNumber(-200),
+					models.NewNumber(8000),
+					models.NewNumber(0),
+					models.NewNumber(0),
+					models.NewNumber(0.01),
+				},
+			},
+			wantResult: 0.007701,
+			wantErr:    "",
+		},
+		{
+			name: "rate/success with a zero rate",
+			args: args{
+				name: "rate",
+				arguments: []models.Value{
+					models.NewNumber(12),
+					models.NewNumber(-100),
+					models.NewNumber(1200),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "",
+		},
+		{
+			name: "rate/error",
+			args: args{
+				name: "rate",
+				arguments: []models.Value{
+					models.NewNumber(10),
+					models.NewNumber(0),
+					models.NewNumber(100),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "solver didn't converge from the guess 0.1",
+		},
+		{
+			name: "npv/success",
+			args: args{
+				name: "npv",
+				arguments: []models.Value{
+					models.NewNumber(0.1),
+					models.NewNumber(-10000),
+					models.NewNumber(3000),
+					models.NewNumber(4200),
+					models.NewNumber(6800),
+				},
+			},
+			wantResult: 1188.443412,
+			wantErr:    "",
+		},
+		{
+			name: "npv/success with an array",
+			args: args{
+				name: "npv",
+				arguments: []models.Value{
+					models.NewNumber(0.1),
+					models.NewArray(
+						models.NewNumber(-10000),
+						models.NewNumber(3000),
+						models.NewNumber(4200),
+						models.NewNumber(6800),
+					),
+				},
+			},
+			wantResult: 1188.443412,
+			wantErr:    "",
+		},
+		{
+			name: "irr/success",
+			args: args{
+				name: "irr",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(-70000),
+						models.NewNumber(12000),
+						models.NewNumber(15000),
+						models.NewNumber(18000),
+						models.NewNumber(21000),
+						models.NewNumber(26000),
+					),
+				},
+			},
+			wantResult: 0.086631,
+			wantErr:    "",
+		},
+		{
+			name: "irr/success with the guess",
+			args: args{
+				name: "irr",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(-70000),
+						models.NewNumber(12000),
+						models.NewNumber(15000),
+					),
+					models.NewNumber(-0.1),
+				},
+			},
+			wantResult: -0.443507,
+			wantErr:    "",
+		},
+		{
+			name: "irr/error with values",
+			args: args{
+				name: "irr",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(1),
+						models.NewNumber(2),
+					),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "values should contain at least one positive and one negative number",
+		},
+		{
+			name: "irr/error with the guess",
+			args: args{
+				name: "irr",
+				arguments: []models.Value{
+					models.NewArray(
+						models.NewNumber(-1),
+						models.NewNumber(2),
+					),
+					models.NewString("test"),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "argument #1 has type string, but number is expected",
+		},
+		{
+			name: "compound/success",
+			args: args{
+				name: "compound",
+				arguments: []models.Value{
+					models.NewNumber(1000),
+					models.NewNumber(0.05),
+					models.NewNumber(10),
+				},
+			},
+			wantResult: 1628.894627,
+			wantErr:    "",
+		},
+		{
+			name: "compound/success with compoundings",
+			args: args{
+				name: "compound",
+				arguments: []models.Value{
+					models.NewNumber(1000),
+					models.NewNumber(0.05),
+					models.NewNumber(10),
+					models.NewNumber(12),
+				},
+			},
+			wantResult: 1647.009498,
+			wantErr:    "",
+		},
+		{
+			name: "compound/error",
+			args: args{
+				name: "compound",
+				arguments: []models.Value{
+					models.NewNumber(1000),
+					models.NewNumber(0.05),
+					models.NewNumber(10),
+					models.NewNumber(0),
+				},
+			},
+			wantResult: 0,
+			wantErr:    "argument #3 should be positive",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotFunction, gotOk := FinanceFunctions[testCase.args.name]
+			require.True(test, gotOk)
+			require.NoError(
+				test,
+				gotFunction.CheckArgumentCount(len(testCase.args.arguments)),
+			)
+
+			gotResult, gotErr := gotFunction.Call(testCase.args.arguments)
+
+			assert.InDelta(test, testCase.wantResult, gotResult.Number, 1e-6)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package calculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+)
+
+// ErrNoModuleLoader is returned for the import statement
+// of an interpreter without a module loader.
+var ErrNoModuleLoader = errors.New("no module loader")
+
+// ModuleLoader loads code of the modules imported by the import statement.
+type ModuleLoader interface {
+	// the path is cleaned, so it identifies the module
+	Load(path string) (code string, err error)
+}
+
+// DirectoryModuleLoader loads modules from files in the directory;
+// paths of the modules are relative to it and can't leave it.
+type DirectoryModuleLoader string
+
+// Load ...
+func (directory DirectoryModuleLoader) Load(modulePath string) (string, error) {
+	if path.IsAbs(modulePath) || modulePath == ".." ||
+		strings.HasPrefix(modulePath, "../") {
+		return "", fmt.Errorf("module path %q is outside the directory", modulePath)
+	}
+
+	code, err := ioutil.ReadFile(
+		filepath.Join(string(directory), filepath.FromSlash(modulePath)),
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the module: %w", err)
+	}
+
+	return string(code), nil
+}
+
+// ImportCycleError is returned when a module imports itself,
+// directly or via other modules.
+type ImportCycleError struct {
+	// the path of the cycle; it starts and ends with the same module
+	Modules []string
+}
+
+// Error ...
+func (err ImportCycleError) Error() string {
+	return fmt.Sprintf("import cycle %s", strings.Join(err.Modules, " -> "))
+}
+
+// it distinguishes the import statement, e.g. `import "geo.code" as geo`,
+// from calls of a function with the same name
+func extractImportStatement(code string) (
+	modulePath string,
+	namespace string,
+	ok bool,
+	err error,
+) {
+	arguments, ok := tokenizer.ExtractKeyword(code, "import")
+	trimmedArguments := strings.TrimLeftFunc(arguments, unicode.IsSpace)
+	if !ok || arguments == trimmedArguments ||
+		!strings.HasPrefix(trimmedArguments, "\"") {
+		return "", "", false, nil
+	}
+
+	tokens, err := tokenizeCode(trimmedArguments)
+	if err != nil {
+		return "", "", true, err
+	}
+	if len(tokens) != 3 ||
+		tokens[0].Kind != models.StringToken ||
+		tokens[1] != (models.Token{Kind: models.IdentifierToken, Value: "as"}) ||
+		tokens[2].Kind != models.IdentifierToken {
+		return "", "", true, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to parse the import statement",
+			Err:     errors.New("it should be `import \"path\" as name`"),
+		}
+	}
+
+	return path.Clean(tokens[0].Value), tokens[2].Value, true, nil
+}
+
+// it interprets the module by a separate interpreter and adds its variables
+// and constants to the namespace; the lock should be held for writing
+func (interpreter Interpreter) importModule(
+	ctx context.Context,
+	modulePath string,
+	namespace string,
+) error {
+	if interpreter.moduleLoader == nil {
+		return ErrNoModuleLoader
+	}
+	for index, importedModule := range interpreter.importedModules {
+		if importedModule == modulePath {
+			modules := append([]string{}, interpreter.importedModules[index:]...)
+			return ImportCycleError{Modules: append(modules, modulePath)}
+		}
+	}
+
+	code, err := interpreter.moduleLoader.Load(modulePath)
+	if err != nil {
+		return fmt.Errorf("unable to load the module %s: %w", modulePath, err)
+	}
+
+	module := interpreter.newModuleInterpreter(modulePath)
+	for lineIndex, line := range strings.Split(code, "\n") {
+		_, err := module.InterpretContext(ctx, strings.TrimRight(line, "\r"))
+		if err != nil && err != ErrNoCode && err != ErrNoValue {
+			return fmt.Errorf(
+				"unable to interpret line #%d of the module %s: %w",
+				lineIndex+1,
+				modulePath,
+				err,
+			)
+		}
+	}
+
+	var names []string
+	definitions := models.VariableGroup{}
+	constants := map[string]struct{}{}
+	for name, value := range module.variables {
+		if _, ok := interpreter.options.constants[name]; ok {
+			continue
+		}
+
+		namespacedName := namespace + "." + name
+		if _, ok := module.constants[name]; ok {
+			constants[namespacedName] = struct{}{}
+		}
+
+		names = append(names, namespacedName)
+		definitions[namespacedName] = value
+	}
+	sort.Strings(names)
+
+	// the definitions are checked before assigning any of them,
+	// so a failed import doesn't change the variables
+	for _, name := range names {
+		_, isConstant := constants[name]
+		violations, err := interpreter.findAssignmentViolations(name, isConstant)
+		if err != nil {
+			return err
+		}
+		if len(violations) != 0 {
+			return &Error{
+				Stage:   TranslationStage,
+				Message: "unable to validate the import",
+				Err:     violations[0],
+			}
+		}
+	}
+
+	newVariableCount := 0
+	for _, name := range names {
+		if _, ok := interpreter.variables[name]; !ok {
+			newVariableCount++
+		}
+	}
+	maxVariableCount := interpreter.limits.MaxVariableCount
+	if maxVariableCount != 0 &&
+		interpreter.variableCount()+newVariableCount > maxVariableCount {
+		return VariableCountError{MaxVariableCount: maxVariableCount}
+	}
+
+	for name, value := range definitions {
+		interpreter.variables[name] = value
+	}
+	for name := range constants {
+		interpreter.constants[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// it's an interpreter with the same configuration and the angle mode,
+// but with its own variables and lock, because the lock isn't reentrant
+func (interpreter Interpreter) newModuleInterpreter(modulePath string) Interpreter {
+	module := interpreter
+	module.variables = models.VariableGroup{}
+	module.constants = map[string]struct{}{}
+	for name, value := range interpreter.options.constants {
+		module.variables[name] = value
+		module.constants[name] = struct{}{}
+	}
+
+	angleMode := *interpreter.angleMode
+	module.angleMode = &angleMode
+	module.lock = &sync.RWMutex{}
+	module.importedModules = append(
+		append([]string{}, interpreter.importedModules...),
+		modulePath,
+	)
+
+	return module
+}
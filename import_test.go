@@ -0,0 +1,273 @@
+package calculator
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type moduleLoaderStub map[string]string
+
+func (modules moduleLoaderStub) Load(path string) (string, error) {
+	code, ok := modules[path]
+	if !ok {
+		return "", errors.New("module not found")
+	}
+
+	return code, nil
+}
+
+func TestInterpreter_withImports(test *testing.T) {
+	type args struct {
+		modules moduleLoaderStub
+		limits  Limits
+		inputs  []string
+	}
+
+	testsCases := []struct {
+		name          string
+		args          args
+		wantVariables models.VariableGroup
+		wantConstants models.ConstantGroup
+		wantValue     models.Value
+		wantErr       string
+	}{
+		{
+			name: "success",
+			args: args{
+				modules: moduleLoaderStub{
+					"geo.code": "// geometry\nconst radius = 10\r\n" +
+						"circle = 2 * pi * radius\nprint \"loaded\"\n",
+				},
+				inputs: []string{
+					"import \"./geo.code\" as geo",
+					"geo.circle + geo.radius",
+				},
+			},
+			wantVariables: models.VariableGroup{"geo.circle": models.NewNumber(60)},
+			wantConstants: models.ConstantGroup{
+				"pi":         models.NewNumber(3),
+				"geo.radius": models.NewNumber(10),
+			},
+			wantValue: models.NewNumber(70),
+			wantErr:   "",
+		},
+		{
+			name: "success with the nested imports",
+			args: args{
+				modules: moduleLoaderStub{
+					"a.code": "import \"b.code\" as b\ny = b.x + 1",
+					"b.code": "x = 2",
+				},
+				inputs: []string{"import \"a.code\" as lib.a", "lib.a.y * lib.a.b.x"},
+			},
+			wantVariables: models.VariableGroup{
+				"lib.a.b.x": models.NewNumber(2),
+				"lib.a.y":   models.NewNumber(3),
+			},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.NewNumber(6),
+			wantErr:       "",
+		},
+		{
+			name: "success with the repeated import",
+			args: args{
+				modules: moduleLoaderStub{
+					"a.code": "x = 2",
+					"b.code": "import \"a.code\" as a\ny = a.x",
+				},
+				inputs: []string{
+					"import \"a.code\" as a",
+					"import \"b.code\" as b",
+					"import \"a.code\" as a",
+					"a.x + b.y",
+				},
+			},
+			wantVariables: models.VariableGroup{
+				"a.x":   models.NewNumber(2),
+				"b.a.x": models.NewNumber(2),
+				"b.y":   models.NewNumber(2),
+			},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.NewNumber(4),
+			wantErr:       "",
+		},
+		{
+			name: "error with the cycle",
+			args: args{
+				modules: moduleLoaderStub{
+					"a.code": "import \"b.code\" as b",
+					"b.code": "x = 2\nimport \"a.code\" as a",
+				},
+				inputs: []string{"import \"a.code\" as a"},
+			},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to import the module: " +
+				"unable to interpret line #1 of the module a.code: " +
+				"unable to import the module: " +
+				"unable to interpret line #2 of the module b.code: " +
+				"unable to import the module: " +
+				"import cycle a.code -> b.code -> a.code",
+		},
+		{
+			name: "error with the self-import",
+			args: args{
+				modules: moduleLoaderStub{"a.code": "import \"a.code\" as a"},
+				inputs:  []string{"import \"a.code\" as a"},
+			},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to import the module: " +
+				"unable to interpret line #1 of the module a.code: " +
+				"unable to import the module: " +
+				"import cycle a.code -> a.code",
+		},
+		{
+			name: "error with the missed module",
+			args: args{
+				modules: moduleLoaderStub{},
+				inputs:  []string{"import \"a.code\" as a"},
+			},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to import the module: " +
+				"unable to load the module a.code: module not found",
+		},
+		{
+			name: "error with the statement",
+			args: args{
+				modules: moduleLoaderStub{"a.code": "x = 2"},
+				inputs:  []string{"import \"a.code\" a"},
+			},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to import the module: " +
+				"unable to parse the import statement: " +
+				"it should be `import \"path\" as name`",
+		},
+		{
+			name: "error with the code of the module",
+			args: args{
+				modules: moduleLoaderStub{"a.code": "x = 2\ny = x +"},
+				inputs:  []string{"import \"a.code\" as a"},
+			},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to import the module: " +
+				"unable to interpret line #2 of the module a.code: " +
+				"unable to finalize the calculator: " +
+				"unable to finalize the translator: " +
+				"missed operand for token {Kind:0 Value:+}",
+		},
+		{
+			name: "success with the limit of variables",
+			args: args{
+				modules: moduleLoaderStub{"a.code": "x = 1\ny = 2"},
+				limits:  Limits{MaxVariableCount: 2},
+				inputs:  []string{"import \"a.code\" as a", "a.x + a.y"},
+			},
+			wantVariables: models.VariableGroup{
+				"a.x": models.NewNumber(1),
+				"a.y": models.NewNumber(2),
+			},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.NewNumber(3),
+			wantErr:       "",
+		},
+		{
+			name: "error with the limit of variables",
+			args: args{
+				modules: moduleLoaderStub{"a.code": "x = 1\ny = 2"},
+				limits:  Limits{MaxVariableCount: 2},
+				inputs:  []string{"b = 1", "import \"a.code\" as a"},
+			},
+			wantVariables: models.VariableGroup{"b": models.NewNumber(1)},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to import the module: " +
+				"variable count exceeds the limit of 2",
+		},
+		{
+			name: "error with the constant",
+			args: args{
+				modules: moduleLoaderStub{"a.code": "const c = 2"},
+				inputs:  []string{"import \"a.code\" as a", "import \"a.code\" as a"},
+			},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{
+				"pi":  models.NewNumber(3),
+				"a.c": models.NewNumber(2),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to import the module: " +
+				"unable to validate the import: constant a.c can't be reassigned",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotErr := models.Value{}, error(nil)
+
+			interpreter := NewInterpreter(
+				models.VariableGroup{},
+				BuiltInFunctions,
+				Constants(models.ConstantGroup{"pi": models.NewNumber(3)}),
+			).
+				WithModuleLoader(testCase.args.modules).
+				WithLimits(testCase.args.limits)
+			for _, input := range testCase.args.inputs {
+				gotValue, gotErr = interpreter.Interpret(input)
+				if gotErr != nil && gotErr != ErrNoValue {
+					break
+				}
+			}
+
+			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
+			assert.Equal(test, testCase.wantConstants, interpreter.Constants())
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestInterpreter_withoutModuleLoader(test *testing.T) {
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions)
+	_, err := interpreter.Interpret("import \"a.code\" as a")
+
+	assert.True(test, errors.Is(err, ErrNoModuleLoader))
+}
+
+func TestDirectoryModuleLoader_Load(test *testing.T) {
+	directory, err := ioutil.TempDir("", "modules")
+	require.NoError(test, err)
+	defer os.RemoveAll(directory)
+
+	err = os.Mkdir(filepath.Join(directory, "lib"), 0755)
+	require.NoError(test, err)
+	err = ioutil.WriteFile(filepath.Join(directory, "lib", "a.code"), []byte("x = 2"), 0644)
+	require.NoError(test, err)
+
+	loader := DirectoryModuleLoader(directory)
+	code, err := loader.Load("lib/a.code")
+	assert.Equal(test, "x = 2", code)
+	assert.NoError(test, err)
+
+	code, err = loader.Load("../a.code")
+	assert.Equal(test, "", code)
+	assert.EqualError(test, err, "module path \"../a.code\" is outside the directory")
+}
@@ -1,58 +1,540 @@
 package calculator
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
+	"github.com/irenicaa/go-calculator/v2/evaluator"
 	"github.com/irenicaa/go-calculator/v2/models"
 	"github.com/irenicaa/go-calculator/v2/tokenizer"
+	"github.com/irenicaa/go-calculator/v2/units"
 )
 
-// ErrNoCode ...
-var ErrNoCode = errors.New("no code")
+// ...
+var (
+	ErrNoCode  = errors.New("no code")
+	ErrNoValue = errors.New("no value")
+)
 
-// Interpreter ...
+// Interpreter executes statements one by one and keeps variables between them.
+//
+// It's safe for concurrent use: Interpret may be called from several
+// goroutines on the same interpreter or on its copies made by the With
+// methods, which share the variables and the angle mode, except copies
+// made by WithAngleMode, which have their own angle mode shared with
+// their further copies. Each statement is atomic, so
+// e.g. "x = x + 1" doesn't lose concurrent increments. The With methods
+// themselves only return copies, so configure the interpreter before
+// sharing it.
 type Interpreter struct {
 	variables models.VariableGroup
 	functions models.FunctionGroup
+	// it's shared by copies of the interpreter like the variables,
+	// because the mode statement changes it; WithAngleMode replaces it
+	angleMode *AngleMode
+	random    *rand.Rand
+	output    io.Writer
+	limits    Limits
+	options   options
+	resolver  evaluator.VariableResolver
+	// it's consulted after the functions, and the angle mode doesn't affect it
+	functionProvider models.FunctionProvider
+	moduleLoader     ModuleLoader
+	// paths of the modules being imported, to detect import cycles
+	importedModules []string
+	// names of the constants; their values are kept with the variables,
+	// and it's shared and guarded like them
+	constants map[string]struct{}
+	// it guards the variables, the constants and the angle mode, and it's shared
+	// by copies of the interpreter; assignments and mode statements
+	// hold it for writing for the whole statement
+	lock *sync.RWMutex
+	// it serializes writes of the print statements
+	outputLock *sync.Mutex
 }
 
 // NewInterpreter ...
 func NewInterpreter(
 	variables models.VariableGroup,
 	functions models.FunctionGroup,
+	options ...Option,
+) Interpreter {
+	interpreterOptions := newOptions(options)
+	interpreterVariables := variables.Copy()
+	constants := map[string]struct{}{}
+	for name, value := range interpreterOptions.constants {
+		interpreterVariables[name] = value
+		constants[name] = struct{}{}
+	}
+
+	angleMode := RadianMode
+	random := newLockedRandom(time.Now().UnixNano())
+	return Interpreter{
+		variables:  interpreterVariables,
+		functions:  bindRandomFunctions(functions, random),
+		angleMode:  &angleMode,
+		random:     random,
+		output:     ioutil.Discard,
+		options:    interpreterOptions,
+		constants:  constants,
+		lock:       &sync.RWMutex{},
+		outputLock: &sync.Mutex{},
+	}
+}
+
+// WithOutput returns a copy of the interpreter
+// that writes an output of the print statements to the specified writer.
+func (interpreter Interpreter) WithOutput(output io.Writer) Interpreter {
+	interpreter.output = output
+	return interpreter
+}
+
+// WithAngleMode returns a copy of the interpreter
+// that uses the specified angle mode in the trigonometric functions;
+// the copy doesn't share the angle mode with the original interpreter,
+// so their mode statements don't affect each other.
+func (interpreter Interpreter) WithAngleMode(mode AngleMode) Interpreter {
+	interpreter.angleMode = &mode
+	return interpreter
+}
+
+// WithSeed returns a copy of the interpreter
+// whose random functions use a generator with the specified seed.
+func (interpreter Interpreter) WithSeed(seed int64) Interpreter {
+	interpreter.random = newLockedRandom(seed)
+	interpreter.functions = bindRandomFunctions(
+		interpreter.functions,
+		interpreter.random,
+	)
+
+	return interpreter
+}
+
+// WithLimits returns a copy of the interpreter
+// that restricts resources of the statements.
+func (interpreter Interpreter) WithLimits(limits Limits) Interpreter {
+	interpreter.limits = limits
+	return interpreter
+}
+
+// WithResolver returns a copy of the interpreter that consults
+// the resolver for variables missed in its variables; results
+// of the resolver are cached for each calculation and aren't assigned
+// to the variables.
+func (interpreter Interpreter) WithResolver(
+	resolver evaluator.VariableResolver,
+) Interpreter {
+	interpreter.resolver = resolver
+	return interpreter
+}
+
+// WithFunctionProvider returns a copy of the interpreter that consults
+// the provider for functions missed in its functions,
+// e.g. for functions loaded dynamically.
+func (interpreter Interpreter) WithFunctionProvider(
+	provider models.FunctionProvider,
 ) Interpreter {
-	return Interpreter{variables: variables.Copy(), functions: functions}
+	interpreter.functionProvider = provider
+	return interpreter
 }
 
-// Variables ...
+// WithModuleLoader returns a copy of the interpreter that loads modules
+// of the import statement by the loader; without a loader, the statement
+// returns ErrNoModuleLoader.
+func (interpreter Interpreter) WithModuleLoader(loader ModuleLoader) Interpreter {
+	interpreter.moduleLoader = loader
+	return interpreter
+}
+
+// AngleMode ...
+func (interpreter Interpreter) AngleMode() AngleMode {
+	interpreter.lock.RLock()
+	defer interpreter.lock.RUnlock()
+
+	return *interpreter.angleMode
+}
+
+// Variables returns a snapshot of the variables without the constants;
+// changing it doesn't affect the interpreter.
 func (interpreter Interpreter) Variables() models.VariableGroup {
-	return interpreter.variables
+	interpreter.lock.RLock()
+	defer interpreter.lock.RUnlock()
+
+	variables := models.VariableGroup{}
+	for name, value := range interpreter.variables {
+		if _, ok := interpreter.constants[name]; !ok {
+			variables[name] = value
+		}
+	}
+
+	return variables
+}
+
+// Constants returns a snapshot of the constants, including ones
+// declared by the const statement.
+func (interpreter Interpreter) Constants() models.ConstantGroup {
+	interpreter.lock.RLock()
+	defer interpreter.lock.RUnlock()
+
+	constants := models.ConstantGroup{}
+	for name := range interpreter.constants {
+		constants[name] = interpreter.variables[name]
+	}
+
+	return constants
 }
 
 // Interpret ...
-func (interpreter Interpreter) Interpret(input string) (float64, error) {
+func (interpreter Interpreter) Interpret(input string) (models.Value, error) {
+	return interpreter.InterpretContext(context.Background(), input)
+}
+
+// InterpretContext stops the statement with an error of the context
+// when the context is done; the variables aren't changed in this case.
+func (interpreter Interpreter) InterpretContext(
+	ctx context.Context,
+	input string,
+) (models.Value, error) {
+	if err := interpreter.limits.checkInputLength(input); err != nil {
+		return models.Value{}, err
+	}
+
 	input = tokenizer.RemoveComment(input)
 
 	variable, code := tokenizer.ExtractVariable(input)
 	if variable == "" && strings.TrimSpace(code) == "" {
-		return 0, ErrNoCode
+		return models.Value{}, ErrNoCode
 	}
 
-	calculator := NewCalculator(interpreter.variables, interpreter.functions)
-	if err := calculator.Calculate(code); err != nil {
-		return 0, fmt.Errorf("unable to calculate the code: %s", err)
+	constant, isConstant := tokenizer.ExtractConstant(variable)
+	if isConstant {
+		variable = constant
 	}
 
-	number, err := calculator.Finalize()
+	if variable == "" {
+		if arguments, ok := tokenizer.ExtractKeyword(code, "print"); ok {
+			interpreter.lock.RLock()
+			defer interpreter.lock.RUnlock()
+
+			if err := interpreter.print(ctx, arguments); err != nil {
+				return models.Value{}, fmt.Errorf("unable to print: %w", err)
+			}
+
+			return models.Value{}, ErrNoValue
+		}
+		if modeName, ok := extractModeStatement(code); ok {
+			violations := interpreter.options.findModeViolations(modeName)
+			if len(violations) != 0 {
+				return models.Value{}, fmt.Errorf("unable to set the mode: %w", &Error{
+					Stage:   TranslationStage,
+					Message: "unable to validate the mode statement",
+					Err:     violations[0],
+				})
+			}
+
+			interpreter.lock.Lock()
+			defer interpreter.lock.Unlock()
+
+			if err := interpreter.setAngleMode(modeName); err != nil {
+				return models.Value{}, fmt.Errorf("unable to set the mode: %w", err)
+			}
+
+			return models.Value{}, ErrNoValue
+		}
+
+		modulePath, namespace, ok, err := extractImportStatement(code)
+		if err != nil {
+			return models.Value{}, fmt.Errorf("unable to import the module: %w", err)
+		}
+		if ok {
+			interpreter.lock.Lock()
+			defer interpreter.lock.Unlock()
+
+			if err := interpreter.importModule(ctx, modulePath, namespace); err != nil {
+				return models.Value{}, fmt.Errorf("unable to import the module: %w", err)
+			}
+
+			return models.Value{}, ErrNoValue
+		}
+	}
+
+	// the assignment holds the lock since the calculation,
+	// so concurrent statements can't change the variables in between
+	if variable != "" {
+		interpreter.lock.Lock()
+		defer interpreter.lock.Unlock()
+	} else {
+		interpreter.lock.RLock()
+		defer interpreter.lock.RUnlock()
+	}
+
+	if variable != "" {
+		violations, err := interpreter.findAssignmentViolations(variable, isConstant)
+		if err != nil {
+			return models.Value{}, fmt.Errorf("unable to assign the variable: %w", err)
+		}
+		if len(violations) != 0 {
+			return models.Value{}, fmt.Errorf("unable to assign the variable: %w", &Error{
+				Stage:   TranslationStage,
+				Message: "unable to validate the assignment",
+				Err:     violations[0],
+			})
+		}
+	}
+
+	code, unitCode, isConversion := tokenizer.SplitAtKeyword(code, "to")
+	value, err := interpreter.calculate(ctx, code)
 	if err != nil {
-		return 0, fmt.Errorf("unable to finalize the calculator: %s", err)
+		return models.Value{}, err
+	}
+
+	if isConversion {
+		value, err = convert(value, unitCode)
+		if err != nil {
+			return models.Value{}, fmt.Errorf("unable to convert the value: %w", err)
+		}
 	}
 
 	if variable != "" {
-		interpreter.variables[variable] = number
+		if err := interpreter.assign(ctx, variable, value); err != nil {
+			return models.Value{}, fmt.Errorf("unable to assign the variable: %w", err)
+		}
+		if isConstant {
+			interpreter.constants[variable] = struct{}{}
+		}
+	}
+
+	return value, nil
+}
+
+func (interpreter Interpreter) calculate(
+	ctx context.Context,
+	code string,
+) (models.Value, error) {
+	calculator := interpreter.newCalculator(ctx, interpreter.wrapFunctions())
+	if err := calculator.Calculate(code); err != nil {
+		return models.Value{}, fmt.Errorf("unable to calculate the code: %w", err)
+	}
+
+	value, err := calculator.Finalize()
+	if err != nil {
+		return models.Value{}, fmt.Errorf("unable to finalize the calculator: %w", err)
+	}
+
+	return value, nil
+}
+
+func (interpreter Interpreter) newCalculator(
+	ctx context.Context,
+	functions models.FunctionProvider,
+) *Calculator {
+	calculator := NewCalculator(interpreter.variables, functions)
+	calculator.options = interpreter.options
+	calculator.ctx = ctx
+	calculator.evaluator = evaluator.NewEvaluator(
+		interpreter.limits.evaluatorLimits(),
+	)
+	if interpreter.resolver != nil {
+		calculator.evaluator = calculator.evaluator.WithResolver(interpreter.resolver)
+	}
+
+	return calculator
+}
+
+func (interpreter Interpreter) wrapFunctions() models.FunctionProvider {
+	return interpreter.chainFunctions(
+		interpreter.angleMode.WrapFunctions(interpreter.functions),
+	)
+}
+
+// it doesn't read the angle mode, so it's suitable for translation
+// without the lock
+func (interpreter Interpreter) allFunctions() models.FunctionProvider {
+	return interpreter.chainFunctions(interpreter.functions)
+}
+
+func (interpreter Interpreter) chainFunctions(
+	functions models.FunctionGroup,
+) models.FunctionProvider {
+	if interpreter.functionProvider == nil {
+		return functions
+	}
+
+	return models.FunctionProviderChain{functions, interpreter.functionProvider}
+}
+
+// it distinguishes the mode statement, e.g. "mode deg",
+// from calls of a function with the same name
+func extractModeStatement(code string) (modeName string, ok bool) {
+	arguments, ok := tokenizer.ExtractKeyword(code, "mode")
+	if !ok || arguments == strings.TrimLeftFunc(arguments, unicode.IsSpace) {
+		return "", false
+	}
+
+	modeName = strings.TrimSpace(arguments)
+	if modeName == "" || strings.IndexFunc(modeName, isNotLetter) != -1 {
+		return "", false
+	}
+
+	return modeName, true
+}
+
+func isNotLetter(symbol rune) bool {
+	return !unicode.IsLetter(symbol)
+}
+
+func (interpreter Interpreter) setAngleMode(modeName string) error {
+	mode, err := ParseAngleMode(modeName)
+	if err != nil {
+		return &Error{
+			Stage:   TranslationStage,
+			Message: "unable to parse the angle mode",
+			Err:     err,
+		}
+	}
+
+	*interpreter.angleMode = mode
+	return nil
+}
+
+func convert(value models.Value, unitCode string) (models.Value, error) {
+	unit, err := units.BuiltInUnits.Parse(unitCode)
+	if err != nil {
+		return models.Value{}, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to parse the unit",
+			Err:     err,
+		}
+	}
+
+	value, err = value.WithUnit(unit)
+	if err != nil {
+		return models.Value{}, &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to set the unit",
+			Err:     err,
+		}
+	}
+
+	return value, nil
+}
+
+func (interpreter Interpreter) assign(
+	ctx context.Context,
+	variable string,
+	value models.Value,
+) error {
+	name, indexesCodes, err := extractIndexes(variable)
+	if err != nil {
+		return err
+	}
+	if err := interpreter.checkVariableCount(name); err != nil {
+		return err
+	}
+	if len(indexesCodes) == 0 {
+		interpreter.variables[name] = value
+		return nil
+	}
+
+	indexes := []models.Value{}
+	for indexNumber, indexCode := range indexesCodes {
+		index, err := interpreter.calculate(ctx, indexCode)
+		if err != nil {
+			return fmt.Errorf("unable to calculate the index #%d: %w", indexNumber, err)
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	array, ok := interpreter.variables[name]
+	if !ok {
+		array = models.NewArray()
+	}
+
+	array, err = array.WithElement(indexes, value)
+	if err != nil {
+		return &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to set the element",
+			Err:     err,
+		}
+	}
+	if err := interpreter.limits.checkElementCount(array); err != nil {
+		return err
+	}
+
+	interpreter.variables[name] = array
+	return nil
+}
+
+func extractIndexes(variable string) (
+	name string,
+	indexesCodes []string,
+	err error,
+) {
+	name, indexesCodes, err = tokenizer.ExtractIndexes(variable)
+	if err != nil {
+		return "", nil, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to extract the indexes",
+			Err:     err,
+		}
+	}
+
+	return name, indexesCodes, nil
+}
+
+func (interpreter Interpreter) checkVariableCount(name string) error {
+	maxVariableCount := interpreter.limits.MaxVariableCount
+	if _, ok := interpreter.variables[name]; ok || maxVariableCount == 0 {
+		return nil
+	}
+	if interpreter.variableCount() >= maxVariableCount {
+		return VariableCountError{MaxVariableCount: maxVariableCount}
+	}
+
+	return nil
+}
+
+// constants of the options aren't counted, because they're defined
+// by the host, not by the code
+func (interpreter Interpreter) variableCount() int {
+	return len(interpreter.variables) - len(interpreter.options.constants)
+}
+
+// it checks the options and the constants; a constant can't be declared
+// by its elements
+func (interpreter Interpreter) findAssignmentViolations(
+	variable string,
+	isConstant bool,
+) ([]Violation, error) {
+	name, indexesCodes, err := extractIndexes(variable)
+	if err != nil {
+		return nil, err
+	}
+	if isConstant && len(indexesCodes) != 0 {
+		return nil, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to declare the constant",
+			Err:     errors.New("constant can't be declared by its elements"),
+		}
+	}
+
+	violations := interpreter.options.findAssignmentViolations(name)
+	if _, ok := interpreter.constants[name]; ok {
+		violations = append(violations, Violation{
+			Kind: ConstantAssignmentViolation,
+			Name: name,
+		})
 	}
 
-	return number, nil
+	return violations, nil
 }
@@ -1,10 +1,19 @@
 package calculator
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/irenicaa/go-calculator/v2/evaluator"
 	"github.com/irenicaa/go-calculator/v2/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInterpreter(test *testing.T) {
@@ -21,7 +30,7 @@ func TestInterpreter(test *testing.T) {
 		fields        fields
 		args          args
 		wantVariables models.VariableGroup
-		wantNumber    float64
+		wantValue     models.Value
 		wantErr       string
 	}{
 		{
@@ -39,13 +48,13 @@ func TestInterpreter(test *testing.T) {
 			},
 			args:          args{input: "2 + 3"},
 			wantVariables: models.VariableGroup{},
-			wantNumber:    5,
+			wantValue:     models.NewNumber(5),
 			wantErr:       "",
 		},
 		{
 			name: "success with the use of variables",
 			fields: fields{
-				variables: models.VariableGroup{"x": 2, "y": 3},
+				variables: models.VariableGroup{"x": models.NewNumber(2), "y": models.NewNumber(3)},
 				functions: models.FunctionGroup{
 					"+": {
 						Arity: 2,
@@ -56,14 +65,14 @@ func TestInterpreter(test *testing.T) {
 				},
 			},
 			args:          args{input: "x + y"},
-			wantVariables: models.VariableGroup{"x": 2, "y": 3},
-			wantNumber:    5,
+			wantVariables: models.VariableGroup{"x": models.NewNumber(2), "y": models.NewNumber(3)},
+			wantValue:     models.NewNumber(5),
 			wantErr:       "",
 		},
 		{
 			name: "success with the definition of variables",
 			fields: fields{
-				variables: models.VariableGroup{"x": 2, "y": 3},
+				variables: models.VariableGroup{"x": models.NewNumber(2), "y": models.NewNumber(3)},
 				functions: models.FunctionGroup{
 					"+": {
 						Arity: 2,
@@ -74,10 +83,143 @@ func TestInterpreter(test *testing.T) {
 				},
 			},
 			args:          args{input: "z = x + y"},
-			wantVariables: models.VariableGroup{"x": 2, "y": 3, "z": 5},
-			wantNumber:    5,
+			wantVariables: models.VariableGroup{"x": models.NewNumber(2), "y": models.NewNumber(3), "z": models.NewNumber(5)},
+			wantValue:     models.NewNumber(5),
 			wantErr:       "",
 		},
+		{
+			name: "success with the definition of string variables",
+			fields: fields{
+				variables: models.VariableGroup{"x": models.NewString("one")},
+				functions: BuiltInFunctions,
+			},
+			args: args{input: `y = concat(x, "=two")`},
+			wantVariables: models.VariableGroup{
+				"x": models.NewString("one"),
+				"y": models.NewString("one=two"),
+			},
+			wantValue: models.NewString("one=two"),
+			wantErr:   "",
+		},
+		{
+			name: "success with the definition of array elements",
+			fields: fields{
+				variables: models.VariableGroup{
+					"x": models.NewArray(models.NewNumber(2)),
+					"i": models.NewNumber(1),
+				},
+				functions: BuiltInFunctions,
+			},
+			args: args{input: "x[i + 1] = x[0] * 3"},
+			wantVariables: models.VariableGroup{
+				"x": models.NewArray(
+					models.NewNumber(2),
+					models.NewNumber(0),
+					models.NewNumber(6),
+				),
+				"i": models.NewNumber(1),
+			},
+			wantValue: models.NewNumber(6),
+			wantErr:   "",
+		},
+		{
+			name: "success with the definition of a new array",
+			fields: fields{
+				variables: models.VariableGroup{},
+				functions: BuiltInFunctions,
+			},
+			args: args{input: "x[1][0] = 5"},
+			wantVariables: models.VariableGroup{
+				"x": models.NewArray(
+					models.NewArray(),
+					models.NewArray(models.NewNumber(5)),
+				),
+			},
+			wantValue: models.NewNumber(5),
+			wantErr:   "",
+		},
+		{
+			name: "success with an array literal",
+			fields: fields{
+				variables: models.VariableGroup{},
+				functions: BuiltInFunctions,
+			},
+			args: args{input: "x = [1, 2] * 2 + [[3], 4]"},
+			wantVariables: models.VariableGroup{
+				"x": models.NewArray(
+					models.NewArray(models.NewNumber(5)),
+					models.NewNumber(8),
+				),
+			},
+			wantValue: models.NewArray(
+				models.NewArray(models.NewNumber(5)),
+				models.NewNumber(8),
+			),
+			wantErr: "",
+		},
+		{
+			name: "success in the complex number mode",
+			fields: fields{
+				variables: ComplexVariables.Copy(),
+				functions: BuiltInFunctions.Merge(ComplexFunctions),
+			},
+			args: args{input: "x = sqrt(0 - 4) * (3 + 4i)"},
+			wantVariables: models.VariableGroup{
+				"i": models.NewComplex(1i),
+				"x": models.NewComplex(-8 + 6i),
+			},
+			wantValue: models.NewComplex(-8 + 6i),
+			wantErr:   "",
+		},
+		{
+			name: "success with units in the complex number mode",
+			fields: fields{
+				variables: ComplexVariables.Copy(),
+				functions: BuiltInFunctions.Merge(ComplexFunctions),
+			},
+			args: args{input: "x = sqrt(4 m^2) * 3"},
+			wantVariables: models.VariableGroup{
+				"i": models.NewComplex(1i),
+				"x": models.NewQuantity(6, models.Dimension{models.LengthDimension: 1}),
+			},
+			wantValue: models.NewQuantity(6, models.Dimension{models.LengthDimension: 1}),
+			wantErr:   "",
+		},
+		{
+			name: "success with the conversion of units",
+			fields: fields{
+				variables: models.VariableGroup{},
+				functions: BuiltInFunctions,
+			},
+			args: args{input: "x = 3 km / 20 min to km/h"},
+			wantVariables: models.VariableGroup{
+				"x": {
+					Kind:   models.QuantityValue,
+					Number: 2.5,
+					Unit: models.Unit{
+						Name:   "km/h",
+						Factor: 1000.0 / 3600,
+						Dimension: models.Dimension{
+							models.LengthDimension: 1,
+							models.TimeDimension:   -1,
+						},
+					},
+				},
+			},
+			wantValue: models.Value{
+				Kind:   models.QuantityValue,
+				Number: 2.5,
+				Unit: models.Unit{
+					Name:   "km/h",
+					Factor: 1000.0 / 3600,
+					Dimension: models.Dimension{
+						models.LengthDimension: 1,
+						models.TimeDimension:   -1,
+					},
+				},
+			},
+			wantErr: "",
+		},
 		{
 			name: "success with the comment",
 			fields: fields{
@@ -93,11 +235,25 @@ func TestInterpreter(test *testing.T) {
 			},
 			args:          args{input: "2 + 3 // test"},
 			wantVariables: models.VariableGroup{},
-			wantNumber:    5,
+			wantValue:     models.NewNumber(5),
 			wantErr:       "",
 		},
 
 		// errors
+		{
+			name: "error with a unit name not after a number",
+			fields: fields{
+				variables: models.VariableGroup{"x": models.NewNumber(5)},
+				functions: BuiltInFunctions,
+			},
+			args:          args{input: "x * g"},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(5)},
+			wantValue:     models.Value{},
+			wantErr: "unable to finalize the calculator: " +
+				"unable to evaluate the commands: " +
+				"unknown variable in command " +
+				"{Kind:1 Operand:g ArgumentCount:0} with number #0",
+		},
 		{
 			name: "error with the empty input",
 			fields: fields{
@@ -106,7 +262,7 @@ func TestInterpreter(test *testing.T) {
 			},
 			args:          args{input: ""},
 			wantVariables: models.VariableGroup{},
-			wantNumber:    0,
+			wantValue:     models.Value{},
 			wantErr:       ErrNoCode.Error(),
 		},
 		{
@@ -117,7 +273,7 @@ func TestInterpreter(test *testing.T) {
 			},
 			args:          args{input: "// test"},
 			wantVariables: models.VariableGroup{},
-			wantNumber:    0,
+			wantValue:     models.Value{},
 			wantErr:       ErrNoCode.Error(),
 		},
 		{
@@ -128,11 +284,37 @@ func TestInterpreter(test *testing.T) {
 			},
 			args:          args{input: "2 @ 3"},
 			wantVariables: models.VariableGroup{},
-			wantNumber:    0,
+			wantValue:     models.Value{},
 			wantErr: "unable to calculate the code: " +
 				"unable to tokenize the code: " +
 				"unknown symbol '@' at position 2",
 		},
+		{
+			name: "error with parsing of the unit",
+			fields: fields{
+				variables: models.VariableGroup{},
+				functions: BuiltInFunctions,
+			},
+			args:          args{input: "x = 2 m to unknown"},
+			wantVariables: models.VariableGroup{},
+			wantValue:     models.Value{},
+			wantErr: "unable to convert the value: " +
+				"unable to parse the unit: " +
+				"term #0: unknown unit \"unknown\"",
+		},
+		{
+			name: "error with the conversion of units",
+			fields: fields{
+				variables: models.VariableGroup{},
+				functions: BuiltInFunctions,
+			},
+			args:          args{input: "x = 2 m to s"},
+			wantVariables: models.VariableGroup{},
+			wantValue:     models.Value{},
+			wantErr: "unable to convert the value: " +
+				"unable to set the unit: " +
+				"dimensions are different: m and s",
+		},
 		{
 			name: "error with finalizing of calculation",
 			fields: fields{
@@ -141,11 +323,39 @@ func TestInterpreter(test *testing.T) {
 			},
 			args:          args{input: "2 + ."},
 			wantVariables: models.VariableGroup{},
-			wantNumber:    0,
+			wantValue:     models.Value{},
 			wantErr: "unable to finalize the calculator: " +
 				"unable to finalize the tokenizer: " +
 				"both integer and fractional parts are empty at EOI",
 		},
+		{
+			name: "error with an index of the variable",
+			fields: fields{
+				variables: models.VariableGroup{},
+				functions: nil,
+			},
+			args:          args{input: "x[y] = 5"},
+			wantVariables: models.VariableGroup{},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to calculate the index #0: " +
+				"unable to finalize the calculator: " +
+				"unable to evaluate the commands: " +
+				"unknown variable in command {Kind:1 Operand:y ArgumentCount:0} with number #0",
+		},
+		{
+			name: "error with setting of the element",
+			fields: fields{
+				variables: models.VariableGroup{"x": models.NewNumber(2)},
+				functions: nil,
+			},
+			args:          args{input: "x[0] = 5"},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(2)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to set the element: " +
+				"unable to index the value of type number",
+		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
@@ -155,11 +365,11 @@ func TestInterpreter(test *testing.T) {
 				testCase.fields.variables,
 				testCase.fields.functions,
 			)
-			gotNumber, gotErr := interpreter.Interpret(testCase.args.input)
+			gotValue, gotErr := interpreter.Interpret(testCase.args.input)
 
 			assert.Equal(test, copyOfVariables, testCase.fields.variables)
 			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
-			assert.Equal(test, testCase.wantNumber, gotNumber)
+			assert.Equal(test, testCase.wantValue, gotValue)
 			if testCase.wantErr == "" {
 				assert.NoError(test, gotErr)
 			} else {
@@ -183,7 +393,7 @@ func TestInterpreter_withSequentialCalls(test *testing.T) {
 		fields        fields
 		args          args
 		wantVariables models.VariableGroup
-		wantNumber    float64
+		wantValue     models.Value
 	}{
 		{
 			name: "success",
@@ -201,21 +411,21 @@ func TestInterpreter_withSequentialCalls(test *testing.T) {
 			args: args{
 				inputs: []string{"x = 5 + 12", "y = x + 23", "z = y + 42"},
 			},
-			wantVariables: models.VariableGroup{"x": 17, "y": 40, "z": 82},
-			wantNumber:    82,
+			wantVariables: models.VariableGroup{"x": models.NewNumber(17), "y": models.NewNumber(40), "z": models.NewNumber(82)},
+			wantValue:     models.NewNumber(82),
 		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
 			copyOfVariables := testCase.fields.variables.Copy()
-			gotNumber, gotErr := 0.0, error(nil)
+			gotValue, gotErr := models.Value{}, error(nil)
 
 			interpreter := NewInterpreter(
 				testCase.fields.variables,
 				testCase.fields.functions,
 			)
 			for _, input := range testCase.args.inputs {
-				gotNumber, gotErr = interpreter.Interpret(input)
+				gotValue, gotErr = interpreter.Interpret(input)
 				if gotErr != nil {
 					break
 				}
@@ -223,8 +433,464 @@ func TestInterpreter_withSequentialCalls(test *testing.T) {
 
 			assert.Equal(test, copyOfVariables, testCase.fields.variables)
 			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
-			assert.Equal(test, testCase.wantNumber, gotNumber)
+			assert.Equal(test, testCase.wantValue, gotValue)
 			assert.NoError(test, gotErr)
 		})
 	}
 }
+
+// it's intended to be run with the race detector
+func TestInterpreter_withConcurrentCalls(test *testing.T) {
+	const goroutineCount = 8
+	const iterationCount = 100
+
+	var output bytes.Buffer
+	interpreter := NewInterpreter(
+		models.VariableGroup{"counter": models.NewNumber(0)},
+		BuiltInFunctions,
+	).
+		WithOutput(&output).
+		WithSeed(23)
+
+	var waitGroup sync.WaitGroup
+	for goroutineIndex := 0; goroutineIndex < goroutineCount; goroutineIndex++ {
+		// copies of the interpreter share its variables
+		goroutineInterpreter := interpreter
+		if goroutineIndex%2 == 0 {
+			goroutineInterpreter = interpreter.WithOutput(&output)
+		}
+
+		waitGroup.Add(1)
+		go func(goroutineIndex int, interpreter Interpreter) {
+			defer waitGroup.Done()
+
+			variable := fmt.Sprintf("x%d", goroutineIndex)
+			for iteration := 0; iteration < iterationCount; iteration++ {
+				_, err := interpreter.Interpret("counter = counter + 1")
+				require.NoError(test, err)
+
+				_, err = interpreter.Interpret(fmt.Sprintf("%s = %d", variable, iteration))
+				require.NoError(test, err)
+
+				value, err := interpreter.Interpret(variable + " * 2")
+				require.NoError(test, err)
+				assert.Equal(test, models.NewNumber(float64(2*iteration)), value)
+
+				_, err = interpreter.Interpret("array[2] = rand() + seed(42)")
+				require.NoError(test, err)
+
+				_, err = interpreter.Interpret("print \"#\"")
+				require.Equal(test, ErrNoValue, err)
+
+				mode := []string{"rad", "deg"}[iteration%2]
+				_, err = interpreter.Interpret("mode " + mode)
+				require.Equal(test, ErrNoValue, err)
+
+				value, err = interpreter.Interpret("sin(0)")
+				require.NoError(test, err)
+				assert.Equal(test, models.NewNumber(0), value)
+
+				interpreter.AngleMode()
+				interpreter.Variables()
+			}
+		}(goroutineIndex, goroutineInterpreter)
+	}
+	waitGroup.Wait()
+
+	variables := interpreter.Variables()
+	assert.Equal(
+		test,
+		models.NewNumber(goroutineCount*iterationCount),
+		variables["counter"],
+	)
+	for goroutineIndex := 0; goroutineIndex < goroutineCount; goroutineIndex++ {
+		variable := fmt.Sprintf("x%d", goroutineIndex)
+		assert.Equal(test, models.NewNumber(iterationCount-1), variables[variable])
+	}
+	assert.Equal(
+		test,
+		strings.Repeat("#", goroutineCount*iterationCount),
+		output.String(),
+	)
+}
+
+func TestInterpreter_Variables(test *testing.T) {
+	interpreter := NewInterpreter(
+		models.VariableGroup{"x": models.NewNumber(23)},
+		nil,
+	)
+
+	variables := interpreter.Variables()
+	variables["x"] = models.NewNumber(42)
+
+	assert.Equal(
+		test,
+		models.VariableGroup{"x": models.NewNumber(23)},
+		interpreter.Variables(),
+	)
+}
+
+func TestInterpreter_withLimits(test *testing.T) {
+	type args struct {
+		limits Limits
+		input  string
+	}
+
+	testsCases := []struct {
+		name          string
+		args          args
+		wantVariables models.VariableGroup
+		wantValue     models.Value
+		wantErr       string
+	}{
+		{
+			name: "success",
+			args: args{
+				limits: Limits{
+					MaxInputLength:   25,
+					MaxVariableCount: 2,
+					MaxCommandCount:  6,
+					MaxStackDepth:    3,
+					MaxElementCount:  2,
+				},
+				input: "y[x] = x + 1 // a comment",
+			},
+			wantVariables: models.VariableGroup{
+				"x": models.NewNumber(1),
+				"y": models.NewArray(models.NewNumber(0), models.NewNumber(2)),
+			},
+			wantValue: models.NewNumber(2),
+			wantErr:   "",
+		},
+		{
+			name: "success with the existing variable",
+			args: args{
+				limits: Limits{MaxVariableCount: 1},
+				input:  "x = x + 1",
+			},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(2)},
+			wantValue:     models.NewNumber(2),
+			wantErr:       "",
+		},
+		{
+			name: "error with the input length",
+			args: args{
+				limits: Limits{MaxInputLength: 10},
+				input:  "x + 1 // a comment",
+			},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(1)},
+			wantValue:     models.Value{},
+			wantErr:       "input length exceeds the limit of 10",
+		},
+		{
+			name: "error with the variable count",
+			args: args{
+				limits: Limits{MaxVariableCount: 1},
+				input:  "y = x + 1",
+			},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(1)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"variable count exceeds the limit of 1",
+		},
+		{
+			name: "error with the element count",
+			args: args{
+				limits: Limits{MaxElementCount: 3},
+				input:  "y[1][2] = 5",
+			},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(1)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"element count exceeds the limit of 3",
+		},
+		{
+			name: "error with the command count",
+			args: args{
+				limits: Limits{MaxCommandCount: 2},
+				input:  "y = x + 1",
+			},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(1)},
+			wantValue:     models.Value{},
+			wantErr: "unable to finalize the calculator: " +
+				"unable to evaluate the commands: " +
+				"unable to execute command " +
+				"{Kind:2 Operand:+ ArgumentCount:0} with number #1: " +
+				"command count exceeds the limit of 2",
+		},
+		{
+			name: "error with the stack depth",
+			args: args{
+				limits: Limits{MaxStackDepth: 1},
+				input:  "print x + 1",
+			},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(1)},
+			wantValue:     models.Value{},
+			wantErr: "unable to print: " +
+				"unable to evaluate the commands: " +
+				"unable to execute command " +
+				"{Kind:2 Operand:+ ArgumentCount:0} with number #2: " +
+				"stack depth exceeds the limit of 1",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			// the constants don't count toward the limit of variables
+			interpreter := NewInterpreter(
+				models.VariableGroup{"x": models.NewNumber(1)},
+				BuiltInFunctions,
+				Constants(BuiltInConstants),
+			).
+				WithLimits(testCase.args.limits)
+			gotValue, gotErr := interpreter.Interpret(testCase.args.input)
+
+			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestInterpreter_InterpretContext(test *testing.T) {
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions)
+
+	test.Run("with the deadline", func(test *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		// it takes much longer than the timeout
+		_, err := interpreter.InterpretContext(
+			ctx,
+			"x = sum(sum(sin(i) * j, j, 1, 1000), i, 1, 1000)",
+		)
+
+		assert.True(test, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(test, models.VariableGroup{}, interpreter.Variables())
+	})
+
+	test.Run("with the cancelled context", func(test *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := interpreter.InterpretContext(ctx, "2 + 3")
+
+		assert.True(test, errors.Is(err, context.Canceled))
+	})
+
+	test.Run("with the limit", func(test *testing.T) {
+		_, err := interpreter.WithLimits(Limits{MaxCommandCount: 100}).
+			InterpretContext(context.Background(), "sum(k, k, 1, 1000)")
+
+		var commandCountErr evaluator.CommandCountError
+		require.True(test, errors.As(err, &commandCountErr))
+		assert.Equal(test, 100, commandCountErr.MaxCommandCount)
+	})
+}
+
+func TestInterpreter_withConstants(test *testing.T) {
+	type args struct {
+		inputs []string
+	}
+
+	testsCases := []struct {
+		name          string
+		args          args
+		wantVariables models.VariableGroup
+		wantConstants models.ConstantGroup
+		wantValue     models.Value
+		wantErr       string
+	}{
+		{
+			name:          "success with reading",
+			args:          args{inputs: []string{"x = 2 pi"}},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(6)},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.NewNumber(6),
+			wantErr:       "",
+		},
+		{
+			name:          "success with the declaration",
+			args:          args{inputs: []string{"x = 2", "const c = x + pi"}},
+			wantVariables: models.VariableGroup{"x": models.NewNumber(2)},
+			wantConstants: models.ConstantGroup{
+				"pi": models.NewNumber(3),
+				"c":  models.NewNumber(5),
+			},
+			wantValue: models.NewNumber(5),
+			wantErr:   "",
+		},
+		{
+			name:          "success with the declaration of the existing variable",
+			args:          args{inputs: []string{"x = 2", "const x = 3"}},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{
+				"pi": models.NewNumber(3),
+				"x":  models.NewNumber(3),
+			},
+			wantValue: models.NewNumber(3),
+			wantErr:   "",
+		},
+		{
+			name:          "success with the variable named as the keyword",
+			args:          args{inputs: []string{"const = 2"}},
+			wantVariables: models.VariableGroup{"const": models.NewNumber(2)},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.NewNumber(2),
+			wantErr:       "",
+		},
+		{
+			name:          "error with the assignment",
+			args:          args{inputs: []string{"pi = 4"}},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"constant pi can't be reassigned",
+		},
+		{
+			name:          "error with the assignment of the element",
+			args:          args{inputs: []string{"pi[0] = 4"}},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"constant pi can't be reassigned",
+		},
+		{
+			name:          "error with the assignment of the declared constant",
+			args:          args{inputs: []string{"const c = 2", "c = 3"}},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{
+				"pi": models.NewNumber(3),
+				"c":  models.NewNumber(2),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"constant c can't be reassigned",
+		},
+		{
+			name:          "error with the redeclaration",
+			args:          args{inputs: []string{"const pi = 4"}},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"constant pi can't be reassigned",
+		},
+		{
+			name:          "error with the declaration of the element",
+			args:          args{inputs: []string{"const c[0] = 2"}},
+			wantVariables: models.VariableGroup{},
+			wantConstants: models.ConstantGroup{"pi": models.NewNumber(3)},
+			wantValue:     models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to declare the constant: " +
+				"constant can't be declared by its elements",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotErr := models.Value{}, error(nil)
+
+			interpreter := NewInterpreter(
+				models.VariableGroup{},
+				BuiltInFunctions,
+				Constants(models.ConstantGroup{"pi": models.NewNumber(3)}),
+			)
+			for _, input := range testCase.args.inputs {
+				gotValue, gotErr = interpreter.Interpret(input)
+				if gotErr != nil {
+					break
+				}
+			}
+
+			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
+			assert.Equal(test, testCase.wantConstants, interpreter.Constants())
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestInterpreter_WithAngleMode(test *testing.T) {
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions)
+	degreeInterpreter := interpreter.WithAngleMode(DegreeMode)
+	sharedInterpreter := degreeInterpreter.WithOutput(&bytes.Buffer{})
+
+	_, err := sharedInterpreter.Interpret("mode grad")
+	require.Equal(test, ErrNoValue, err)
+
+	// the copy of the copy shares its angle mode, unlike the original
+	assert.Equal(test, RadianMode, interpreter.AngleMode())
+	assert.Equal(test, GradianMode, degreeInterpreter.AngleMode())
+	assert.Equal(test, GradianMode, sharedInterpreter.AngleMode())
+}
+
+func TestInterpreter_WithFunctionProvider(test *testing.T) {
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions).
+		WithAngleMode(DegreeMode).
+		WithFunctionProvider(models.PrefixedFunctionProvider{
+			Prefix: "ext_",
+			Provider: models.NewAllowedFunctionProvider(
+				BuiltInFunctions,
+				"sin",
+				"sum",
+			),
+		})
+
+	// the angle mode doesn't affect the provided functions
+	value, err := interpreter.Interpret("sin(90) + ext_sin(0)")
+	require.NoError(test, err)
+	assert.Equal(test, models.NewNumber(1), value)
+
+	value, err = interpreter.Interpret("ext_sum(k ^ 2, k, 1, 3)")
+	require.NoError(test, err)
+	assert.Equal(test, models.NewNumber(14), value)
+
+	violations, err := interpreter.Validate("x = ext_sum(k, k, 1, 3)")
+	require.NoError(test, err)
+	assert.Empty(test, violations)
+}
+
+func TestInterpreter_withNamespaces(test *testing.T) {
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions).
+		WithFunctionProvider(models.PrefixedFunctionProvider{
+			Prefix:   "math.",
+			Provider: BuiltInFunctions,
+		})
+
+	_, err := interpreter.Interpret("geo.radius = 4")
+	require.NoError(test, err)
+
+	_, err = interpreter.Interpret("geo.to = 5")
+	require.NoError(test, err)
+
+	_, err = interpreter.Interpret("print.x = 2")
+	require.NoError(test, err)
+
+	value, err := interpreter.Interpret("math.sqrt(geo.radius) + 0.5")
+	require.NoError(test, err)
+	assert.Equal(test, models.NewNumber(2.5), value)
+
+	value, err = interpreter.Interpret("geo.to * print.x")
+	require.NoError(test, err)
+	assert.Equal(test, models.NewNumber(10), value)
+
+	assert.Equal(test, models.VariableGroup{
+		"geo.radius": models.NewNumber(4),
+		"geo.to":     models.NewNumber(5),
+		"print.x":    models.NewNumber(2),
+	}, interpreter.Variables())
+}
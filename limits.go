@@ -0,0 +1,93 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/irenicaa/go-calculator/v2/evaluator"
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// Limits restrict resources of the interpreter and of programs,
+// e.g. for untrusted code; zero values mean no limits.
+//
+// The command count and the stack depth are checked by the evaluator,
+// so they're reported as evaluator.CommandCountError
+// and evaluator.StackDepthError.
+type Limits struct {
+	MaxInputLength int // in bytes, including comments
+	// it's checked before a new variable is added; constants
+	// of the Constants option aren't counted
+	MaxVariableCount int
+	// they're checked for each calculation separately, e.g. for each index
+	// of the assignment or each argument of the print statement
+	MaxCommandCount int
+	MaxStackDepth   int
+	// it's checked after an assignment of an element, which may grow
+	// the array; elements of nested arrays are counted too
+	MaxElementCount int
+}
+
+func (limits Limits) evaluatorLimits() evaluator.Limits {
+	return evaluator.Limits{
+		MaxCommandCount: limits.MaxCommandCount,
+		MaxStackDepth:   limits.MaxStackDepth,
+	}
+}
+
+func (limits Limits) checkInputLength(input string) error {
+	if limits.MaxInputLength != 0 && len(input) > limits.MaxInputLength {
+		return InputLengthError{MaxInputLength: limits.MaxInputLength}
+	}
+
+	return nil
+}
+
+// InputLengthError is returned for code longer than the limit.
+type InputLengthError struct {
+	MaxInputLength int
+}
+
+// Error ...
+func (err InputLengthError) Error() string {
+	return fmt.Sprintf("input length exceeds the limit of %d", err.MaxInputLength)
+}
+
+func (limits Limits) checkElementCount(value models.Value) error {
+	if limits.MaxElementCount != 0 &&
+		countElements(value) > limits.MaxElementCount {
+		return ElementCountError{MaxElementCount: limits.MaxElementCount}
+	}
+
+	return nil
+}
+
+func countElements(value models.Value) int {
+	count := len(value.Elements)
+	for _, element := range value.Elements {
+		count += countElements(element)
+	}
+
+	return count
+}
+
+// VariableCountError is returned when there are more variables
+// than the limit allows.
+type VariableCountError struct {
+	MaxVariableCount int
+}
+
+// Error ...
+func (err VariableCountError) Error() string {
+	return fmt.Sprintf("variable count exceeds the limit of %d", err.MaxVariableCount)
+}
+
+// ElementCountError is returned when an array variable has more elements
+// than the limit allows.
+type ElementCountError struct {
+	MaxElementCount int
+}
+
+// Error ...
+func (err ElementCountError) Error() string {
+	return fmt.Sprintf("element count exceeds the limit of %d", err.MaxElementCount)
+}
@@ -0,0 +1,470 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+const singularityTolerance = 1e-12
+
+var errSingularMatrix = errors.New("matrix is singular")
+
+type vector []float64
+
+type matrix [][]float64
+
+func newVector(value models.Value) (vector, error) {
+	if value.Kind != models.ArrayValue {
+		return nil, fmt.Errorf("value of type %s isn't a vector", value.Kind)
+	}
+
+	numbers := make(vector, 0, len(value.Elements))
+	for elementIndex, element := range value.Elements {
+		if element.Kind != models.NumberValue {
+			return nil, fmt.Errorf(
+				"element #%d has type %s, but number is expected",
+				elementIndex,
+				element.Kind,
+			)
+		}
+
+		numbers = append(numbers, element.Number)
+	}
+
+	return numbers, nil
+}
+
+func newMatrix(value models.Value) (matrix, error) {
+	if value.Kind != models.ArrayValue || len(value.Elements) == 0 {
+		return nil, errors.New("value isn't a nonempty matrix")
+	}
+
+	rows := make(matrix, 0, len(value.Elements))
+	for rowIndex, element := range value.Elements {
+		row, err := newVector(element)
+		if err != nil {
+			return nil, fmt.Errorf("row #%d: %w", rowIndex, err)
+		}
+		if len(row) == 0 || len(row) != len(value.Elements[0].Elements) {
+			return nil, fmt.Errorf("row #%d has an incorrect length", rowIndex)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func isMatrix(value models.Value) bool {
+	return value.Kind == models.ArrayValue &&
+		len(value.Elements) != 0 &&
+		value.Elements[0].Kind == models.ArrayValue
+}
+
+func (numbers vector) value() models.Value {
+	elements := make([]models.Value, 0, len(numbers))
+	for _, number := range numbers {
+		elements = append(elements, models.NewNumber(number))
+	}
+
+	return models.NewArray(elements...)
+}
+
+func (numbers vector) dot(other vector) (float64, error) {
+	if len(numbers) != len(other) {
+		return 0, fmt.Errorf(
+			"vector lengths are different: %d and %d",
+			len(numbers),
+			len(other),
+		)
+	}
+
+	result := 0.0
+	for index := range numbers {
+		result += numbers[index] * other[index]
+	}
+
+	return result, nil
+}
+
+func (numbers vector) norm() float64 {
+	result := 0.0
+	for _, number := range numbers {
+		result = math.Hypot(result, number)
+	}
+
+	return result
+}
+
+func (rows matrix) value() models.Value {
+	elements := make([]models.Value, 0, len(rows))
+	for _, row := range rows {
+		elements = append(elements, vector(row).value())
+	}
+
+	return models.NewArray(elements...)
+}
+
+func (rows matrix) copy() matrix {
+	copyOfRows := make(matrix, 0, len(rows))
+	for _, row := range rows {
+		copyOfRows = append(copyOfRows, append(vector(nil), row...))
+	}
+
+	return copyOfRows
+}
+
+func (rows matrix) transpose() matrix {
+	columns := make(matrix, len(rows[0]))
+	for columnIndex := range columns {
+		columns[columnIndex] = make(vector, len(rows))
+		for rowIndex := range rows {
+			columns[columnIndex][rowIndex] = rows[rowIndex][columnIndex]
+		}
+	}
+
+	return columns
+}
+
+func (rows matrix) multiply(other matrix) (matrix, error) {
+	if len(rows[0]) != len(other) {
+		return nil, fmt.Errorf(
+			"matrix sizes are incompatible: %dx%d and %dx%d",
+			len(rows),
+			len(rows[0]),
+			len(other),
+			len(other[0]),
+		)
+	}
+
+	columns := other.transpose()
+	result := make(matrix, 0, len(rows))
+	for _, row := range rows {
+		resultRow := make(vector, 0, len(columns))
+		for _, column := range columns {
+			// the lengths are already checked
+			number, _ := vector(row).dot(column)
+			resultRow = append(resultRow, number)
+		}
+
+		result = append(result, resultRow)
+	}
+
+	return result, nil
+}
+
+func (rows matrix) checkSquare() error {
+	if len(rows) != len(rows[0]) {
+		return fmt.Errorf("matrix %dx%d isn't square", len(rows), len(rows[0]))
+	}
+
+	return nil
+}
+
+// it uses the Gaussian elimination with partial pivoting
+func (rows matrix) determinant() (float64, error) {
+	if err := rows.checkSquare(); err != nil {
+		return 0, err
+	}
+
+	rows = rows.copy()
+	determinant := 1.0
+	for columnIndex := range rows {
+		pivotIndex := rows.pivot(columnIndex)
+		if pivotIndex != columnIndex {
+			rows[pivotIndex], rows[columnIndex] = rows[columnIndex], rows[pivotIndex]
+			determinant = -determinant
+		}
+
+		pivot := rows[columnIndex][columnIndex]
+		if pivot == 0 {
+			return 0, nil
+		}
+
+		determinant *= pivot
+		for rowIndex := columnIndex + 1; rowIndex < len(rows); rowIndex++ {
+			factor := rows[rowIndex][columnIndex] / pivot
+			for index := columnIndex; index < len(rows); index++ {
+				rows[rowIndex][index] -= factor * rows[columnIndex][index]
+			}
+		}
+	}
+
+	return determinant, nil
+}
+
+// it uses the Gauss-Jordan elimination with partial pivoting
+// and returns X for the equation A * X = B, where A is the receiver
+func (rows matrix) solve(other matrix) (matrix, error) {
+	if err := rows.checkSquare(); err != nil {
+		return nil, err
+	}
+	if len(rows) != len(other) {
+		return nil, fmt.Errorf(
+			"matrix sizes are incompatible: %dx%d and %dx%d",
+			len(rows),
+			len(rows[0]),
+			len(other),
+			len(other[0]),
+		)
+	}
+
+	scale := 0.0
+	for _, row := range rows {
+		for _, number := range row {
+			scale = math.Max(scale, math.Abs(number))
+		}
+	}
+
+	rows, other = rows.copy(), other.copy()
+	for columnIndex := range rows {
+		pivotIndex := rows.pivot(columnIndex)
+		if math.Abs(rows[pivotIndex][columnIndex]) <= singularityTolerance*scale {
+			return nil, errSingularMatrix
+		}
+
+		rows[pivotIndex], rows[columnIndex] = rows[columnIndex], rows[pivotIndex]
+		other[pivotIndex], other[columnIndex] = other[columnIndex], other[pivotIndex]
+
+		pivot := rows[columnIndex][columnIndex]
+		for index := range rows[columnIndex] {
+			rows[columnIndex][index] /= pivot
+		}
+		for index := range other[columnIndex] {
+			other[columnIndex][index] /= pivot
+		}
+
+		for rowIndex := range rows {
+			if rowIndex == columnIndex {
+				continue
+			}
+
+			factor := rows[rowIndex][columnIndex]
+			for index := range rows[rowIndex] {
+				rows[rowIndex][index] -= factor * rows[columnIndex][index]
+			}
+			for index := range other[rowIndex] {
+				other[rowIndex][index] -= factor * other[columnIndex][index]
+			}
+		}
+	}
+
+	return other, nil
+}
+
+func (rows matrix) inverse() (matrix, error) {
+	identity := make(matrix, 0, len(rows))
+	for rowIndex := range rows {
+		row := make(vector, len(rows))
+		row[rowIndex] = 1
+
+		identity = append(identity, row)
+	}
+
+	return rows.solve(identity)
+}
+
+func (rows matrix) pivot(columnIndex int) int {
+	pivotIndex := columnIndex
+	for rowIndex := columnIndex + 1; rowIndex < len(rows); rowIndex++ {
+		if math.Abs(rows[rowIndex][columnIndex]) >
+			math.Abs(rows[pivotIndex][columnIndex]) {
+			pivotIndex = rowIndex
+		}
+	}
+
+	return pivotIndex
+}
+
+func dot(arguments []models.Value) (models.Value, error) {
+	if !isMatrix(arguments[0]) && !isMatrix(arguments[1]) {
+		numbers, err := newVector(arguments[0])
+		if err != nil {
+			return models.Value{}, fmt.Errorf("argument #0: %w", err)
+		}
+
+		otherNumbers, err := newVector(arguments[1])
+		if err != nil {
+			return models.Value{}, fmt.Errorf("argument #1: %w", err)
+		}
+
+		result, err := numbers.dot(otherNumbers)
+		if err != nil {
+			return models.Value{}, err
+		}
+
+		return models.NewNumber(result), nil
+	}
+
+	// vectors are treated as a row on the left and as a column on the right
+	isRow, isColumn := false, false
+	operands := [2]matrix{}
+	for argumentIndex, argument := range arguments {
+		if !isMatrix(argument) {
+			numbers, err := newVector(argument)
+			if err != nil {
+				return models.Value{}, fmt.Errorf("argument #%d: %w", argumentIndex, err)
+			}
+			if len(numbers) == 0 {
+				return models.Value{}, fmt.Errorf("argument #%d is empty", argumentIndex)
+			}
+
+			operands[argumentIndex] = matrix{numbers}
+			if argumentIndex == 0 {
+				isRow = true
+			} else {
+				operands[argumentIndex] = operands[argumentIndex].transpose()
+				isColumn = true
+			}
+
+			continue
+		}
+
+		rows, err := newMatrix(argument)
+		if err != nil {
+			return models.Value{}, fmt.Errorf("argument #%d: %w", argumentIndex, err)
+		}
+
+		operands[argumentIndex] = rows
+	}
+
+	result, err := operands[0].multiply(operands[1])
+	if err != nil {
+		return models.Value{}, err
+	}
+	if isRow {
+		return vector(result[0]).value(), nil
+	}
+	if isColumn {
+		return vector(result.transpose()[0]).value(), nil
+	}
+
+	return result.value(), nil
+}
+
+func cross(arguments []models.Value) (models.Value, error) {
+	vectors := [2]vector{}
+	for argumentIndex, argument := range arguments {
+		numbers, err := newVector(argument)
+		if err != nil {
+			return models.Value{}, fmt.Errorf("argument #%d: %w", argumentIndex, err)
+		}
+		if len(numbers) != 3 {
+			return models.Value{}, fmt.Errorf(
+				"argument #%d has length %d, but 3 is expected",
+				argumentIndex,
+				len(numbers),
+			)
+		}
+
+		vectors[argumentIndex] = numbers
+	}
+
+	a, b := vectors[0], vectors[1]
+	result := vector{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+	return result.value(), nil
+}
+
+func norm(arguments []models.Value) (models.Value, error) {
+	if !isMatrix(arguments[0]) {
+		numbers, err := newVector(arguments[0])
+		if err != nil {
+			return models.Value{}, err
+		}
+
+		return models.NewNumber(numbers.norm()), nil
+	}
+
+	// the Frobenius norm
+	rows, err := newMatrix(arguments[0])
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	numbers := vector{}
+	for _, row := range rows {
+		numbers = append(numbers, row...)
+	}
+
+	return models.NewNumber(numbers.norm()), nil
+}
+
+func transpose(arguments []models.Value) (models.Value, error) {
+	rows, err := newMatrix(arguments[0])
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return rows.transpose().value(), nil
+}
+
+func det(arguments []models.Value) (models.Value, error) {
+	rows, err := newMatrix(arguments[0])
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	determinant, err := rows.determinant()
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(determinant), nil
+}
+
+func inv(arguments []models.Value) (models.Value, error) {
+	rows, err := newMatrix(arguments[0])
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	inverse, err := rows.inverse()
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return inverse.value(), nil
+}
+
+func solve(arguments []models.Value) (models.Value, error) {
+	rows, err := newMatrix(arguments[0])
+	if err != nil {
+		return models.Value{}, fmt.Errorf("argument #0: %w", err)
+	}
+
+	if isMatrix(arguments[1]) {
+		other, err := newMatrix(arguments[1])
+		if err != nil {
+			return models.Value{}, fmt.Errorf("argument #1: %w", err)
+		}
+
+		result, err := rows.solve(other)
+		if err != nil {
+			return models.Value{}, err
+		}
+
+		return result.value(), nil
+	}
+
+	numbers, err := newVector(arguments[1])
+	if err != nil {
+		return models.Value{}, fmt.Errorf("argument #1: %w", err)
+	}
+	if len(numbers) == 0 {
+		return models.Value{}, errors.New("argument #1 is empty")
+	}
+
+	result, err := rows.solve(matrix{numbers}.transpose())
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return vector(result.transpose()[0]).value(), nil
+}
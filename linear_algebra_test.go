@@ -0,0 +1,94 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix_determinant(test *testing.T) {
+	testsCases := []struct {
+		name            string
+		rows            matrix
+		wantDeterminant float64
+		wantErr         string
+	}{
+		{
+			name:            "with pivoting",
+			rows:            matrix{{0, 1, 2}, {3, 4, 5}, {6, 7, 9}},
+			wantDeterminant: -3,
+			wantErr:         "",
+		},
+		{
+			name:            "singular",
+			rows:            matrix{{1, 2}, {2, 4}},
+			wantDeterminant: 0,
+			wantErr:         "",
+		},
+		{
+			name:            "error",
+			rows:            matrix{{1, 2}},
+			wantDeterminant: 0,
+			wantErr:         "matrix 1x2 isn't square",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotDeterminant, gotErr := testCase.rows.determinant()
+
+			assert.InDelta(test, testCase.wantDeterminant, gotDeterminant, 1e-6)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatrix_solve(test *testing.T) {
+	testsCases := []struct {
+		name       string
+		rows       matrix
+		other      matrix
+		wantResult matrix
+		wantErr    string
+	}{
+		{
+			name:       "with pivoting",
+			rows:       matrix{{0, 1, 2}, {3, 4, 5}, {6, 7, 9}},
+			other:      matrix{{5}, {17}, {31}},
+			wantResult: matrix{{1}, {1}, {2}},
+			wantErr:    "",
+		},
+		{
+			name:       "error with a singular matrix",
+			rows:       matrix{{1, 2}, {2, 4 + 1e-15}},
+			other:      matrix{{1}, {2}},
+			wantResult: nil,
+			wantErr:    "matrix is singular",
+		},
+		{
+			name:       "error with sizes",
+			rows:       matrix{{1, 2}, {3, 4}},
+			other:      matrix{{1}},
+			wantResult: nil,
+			wantErr:    "matrix sizes are incompatible: 2x2 and 1x1",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotResult, gotErr := testCase.rows.solve(testCase.other)
+
+			assert.Len(test, gotResult, len(testCase.wantResult))
+			for rowIndex, row := range gotResult {
+				assert.InDeltaSlice(test, testCase.wantResult[rowIndex], row, 1e-6)
+			}
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// the factorial of greater numbers overflows float64
+const maxFactorialArgument = 170
+
+func acosh(arguments []float64) (float64, error) {
+	if err := checkLowerBound(arguments, 0, 1); err != nil {
+		return 0, err
+	}
+
+	return math.Acosh(arguments[0]), nil
+}
+
+func atanh(arguments []float64) (float64, error) {
+	if err := checkLowerBound(arguments, 0, -1); err != nil {
+		return 0, err
+	}
+	if err := checkUpperBound(arguments, 0, 1); err != nil {
+		return 0, err
+	}
+
+	return math.Atanh(arguments[0]), nil
+}
+
+func logarithm(arguments []float64) (float64, error) {
+	if err := checkLowerBound(arguments, 0, 0); err != nil {
+		return 0, err
+	}
+	if len(arguments) == 1 {
+		return math.Log(arguments[0]), nil
+	}
+
+	base := arguments[1]
+	if base <= 0 || base == 1 {
+		return 0, errors.New("argument #1 should be positive and not equal to 1")
+	}
+
+	return math.Log(arguments[0]) / math.Log(base), nil
+}
+
+func log2(arguments []float64) (float64, error) {
+	if err := checkLowerBound(arguments, 0, 0); err != nil {
+		return 0, err
+	}
+
+	return math.Log2(arguments[0]), nil
+}
+
+func log10(arguments []float64) (float64, error) {
+	if err := checkLowerBound(arguments, 0, 0); err != nil {
+		return 0, err
+	}
+
+	return math.Log10(arguments[0]), nil
+}
+
+func sign(arguments []float64) (float64, error) {
+	switch {
+	case arguments[0] > 0:
+		return 1, nil
+	case arguments[0] < 0:
+		return -1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func gamma(arguments []float64) (float64, error) {
+	if err := checkGammaArgument(arguments); err != nil {
+		return 0, err
+	}
+
+	return math.Gamma(arguments[0]), nil
+}
+
+func lgamma(arguments []float64) (float64, error) {
+	if err := checkGammaArgument(arguments); err != nil {
+		return 0, err
+	}
+
+	result, _ := math.Lgamma(arguments[0])
+	return result, nil
+}
+
+func factorial(arguments []float64) (float64, error) {
+	if err := checkNonNegativeInteger(arguments, 0); err != nil {
+		return 0, err
+	}
+	if arguments[0] > maxFactorialArgument {
+		return math.Inf(1), nil
+	}
+
+	result := 1.0
+	for factor := 2.0; factor <= arguments[0]; factor++ {
+		result *= factor
+	}
+
+	return result, nil
+}
+
+func binomial(arguments []float64) (float64, error) {
+	for argumentIndex := range arguments {
+		if err := checkNonNegativeInteger(arguments, argumentIndex); err != nil {
+			return 0, err
+		}
+	}
+
+	total, chosen := arguments[0], arguments[1]
+	if chosen > total {
+		return 0, nil
+	}
+
+	chosen = math.Min(chosen, total-chosen)
+	result := 1.0
+	for index := 1.0; index <= chosen && !math.IsInf(result, 1); index++ {
+		result = result * (total - chosen + index) / index
+	}
+
+	// the division may produce rounding errors
+	return math.Round(result), nil
+}
+
+func gcd(arguments []float64) (float64, error) {
+	for argumentIndex := range arguments {
+		if err := checkInteger(arguments, argumentIndex); err != nil {
+			return 0, err
+		}
+	}
+
+	return greatestCommonDivisor(arguments[0], arguments[1]), nil
+}
+
+func lcm(arguments []float64) (float64, error) {
+	divisor, err := gcd(arguments)
+	if err != nil {
+		return 0, err
+	}
+	if divisor == 0 {
+		return 0, nil
+	}
+
+	return math.Abs(arguments[0] / divisor * arguments[1]), nil
+}
+
+func clamp(arguments []float64) (float64, error) {
+	number, minimum, maximum := arguments[0], arguments[1], arguments[2]
+	if minimum > maximum {
+		return 0, errors.New("argument #1 shouldn't be greater than argument #2")
+	}
+
+	return math.Max(minimum, math.Min(number, maximum)), nil
+}
+
+func greatestCommonDivisor(a float64, b float64) float64 {
+	a, b = math.Abs(a), math.Abs(b)
+	for b != 0 {
+		a, b = b, math.Mod(a, b)
+	}
+
+	return a
+}
+
+// the gamma function has poles at non-positive integers
+func checkGammaArgument(arguments []float64) error {
+	number := arguments[0]
+	if number <= 0 && number == math.Trunc(number) {
+		return errors.New("argument #0 shouldn't be a non-positive integer")
+	}
+
+	return nil
+}
+
+func checkInteger(arguments []float64, argumentIndex int) error {
+	number := arguments[argumentIndex]
+	if number != math.Trunc(number) {
+		return fmt.Errorf("argument #%d isn't an integer", argumentIndex)
+	}
+
+	return nil
+}
+
+func checkNonNegativeInteger(arguments []float64, argumentIndex int) error {
+	if err := checkInteger(arguments, argumentIndex); err != nil {
+		return err
+	}
+
+	return checkLowerBound(arguments, argumentIndex, 0)
+}
+
+func checkLowerBound(arguments []float64, argumentIndex int, bound float64) error {
+	// the negated comparison also catches NaN
+	if !(arguments[argumentIndex] >= bound) {
+		return fmt.Errorf("argument #%d should be at least %g", argumentIndex, bound)
+	}
+
+	return nil
+}
+
+func checkUpperBound(arguments []float64, argumentIndex int, bound float64) error {
+	if !(arguments[argumentIndex] <= bound) {
+		return fmt.Errorf("argument #%d should be at most %g", argumentIndex, bound)
+	}
+
+	return nil
+}
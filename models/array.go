@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxArrayGrowth is the count of elements, by which an assignment
+// of an element may grow an array at most, so a large index can't exhaust
+// the memory.
+const MaxArrayGrowth = 1 << 16
+
+// the largest value of the int type; math.MaxInt requires Go 1.17
+const maxInt = int(^uint(0) >> 1)
+
+// NewArray ...
+func NewArray(elements ...Value) Value {
+	if elements == nil {
+		elements = []Value{}
+	}
+
+	return Value{Kind: ArrayValue, Elements: elements}
+}
+
+// Element ...
+func (value Value) Element(index Value) (Value, error) {
+	if value.Kind != ArrayValue {
+		return Value{}, fmt.Errorf("unable to index the value of type %s", value.Kind)
+	}
+
+	elementIndex, err := parseIndex(index)
+	if err != nil {
+		return Value{}, err
+	}
+	if elementIndex >= len(value.Elements) {
+		return Value{}, fmt.Errorf(
+			"index %d is out of range [0, %d)",
+			elementIndex,
+			len(value.Elements),
+		)
+	}
+
+	return value.Elements[elementIndex], nil
+}
+
+// WithElement returns a copy of the array with the replaced element;
+// the array is grown with zeros if it's necessary, but by MaxArrayGrowth
+// elements at most.
+func (value Value) WithElement(indexes []Value, element Value) (Value, error) {
+	if len(indexes) == 0 {
+		return element, nil
+	}
+	if value.Kind != ArrayValue {
+		return Value{}, fmt.Errorf("unable to index the value of type %s", value.Kind)
+	}
+
+	elementIndex, err := parseIndex(indexes[0])
+	if err != nil {
+		return Value{}, err
+	}
+
+	if elementIndex-len(value.Elements) >= MaxArrayGrowth {
+		return Value{}, fmt.Errorf(
+			"index %d grows the array of length %d by more than %d elements",
+			elementIndex,
+			len(value.Elements),
+			MaxArrayGrowth,
+		)
+	}
+
+	elements := make([]Value, len(value.Elements))
+	copy(elements, value.Elements)
+	for len(elements) <= elementIndex {
+		if len(indexes) > 1 {
+			elements = append(elements, NewArray())
+		} else {
+			elements = append(elements, NewNumber(0))
+		}
+	}
+
+	elements[elementIndex], err =
+		elements[elementIndex].WithElement(indexes[1:], element)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return NewArray(elements...), nil
+}
+
+func parseIndex(index Value) (int, error) {
+	if index.Kind != NumberValue {
+		return 0, fmt.Errorf("index has type %s, but number is expected", index.Kind)
+	}
+	if index.Number < 0 || index.Number != math.Trunc(index.Number) {
+		return 0, fmt.Errorf("index %v isn't a non-negative integer", index.Number)
+	}
+	// the negated comparison also catches NaN; the conversion of maxInt
+	// is rounded up, so the bound is excluded
+	if !(index.Number < float64(maxInt)) {
+		return 0, fmt.Errorf("index %v is too large", index.Number)
+	}
+
+	return int(index.Number), nil
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewArray(test *testing.T) {
+	assert.Equal(test, Value{Kind: ArrayValue, Elements: []Value{}}, NewArray())
+	assert.Equal(
+		test,
+		Value{Kind: ArrayValue, Elements: []Value{NewNumber(2)}},
+		NewArray(NewNumber(2)),
+	)
+}
+
+func TestValue_Element(test *testing.T) {
+	type args struct {
+		index Value
+	}
+
+	testsCases := []struct {
+		name        string
+		value       Value
+		args        args
+		wantElement Value
+		wantErr     string
+	}{
+		{
+			name:        "success",
+			value:       NewArray(NewNumber(2), NewString("test")),
+			args:        args{index: NewNumber(1)},
+			wantElement: NewString("test"),
+			wantErr:     "",
+		},
+		{
+			name:        "error with a non-array value",
+			value:       NewNumber(2),
+			args:        args{index: NewNumber(0)},
+			wantElement: Value{},
+			wantErr:     "unable to index the value of type number",
+		},
+		{
+			name:        "error with a non-number index",
+			value:       NewArray(NewNumber(2)),
+			args:        args{index: NewString("test")},
+			wantElement: Value{},
+			wantErr:     "index has type string, but number is expected",
+		},
+		{
+			name:        "error with a negative index",
+			value:       NewArray(NewNumber(2)),
+			args:        args{index: NewNumber(-1)},
+			wantElement: Value{},
+			wantErr:     "index -1 isn't a non-negative integer",
+		},
+		{
+			name:        "error with a fractional index",
+			value:       NewArray(NewNumber(2)),
+			args:        args{index: NewNumber(0.5)},
+			wantElement: Value{},
+			wantErr:     "index 0.5 isn't a non-negative integer",
+		},
+		{
+			name:        "error with a too large index",
+			value:       NewArray(NewNumber(2)),
+			args:        args{index: NewNumber(1e300)},
+			wantElement: Value{},
+			wantErr:     "index 1e+300 is too large",
+		},
+		{
+			name:        "error with an index out of range",
+			value:       NewArray(NewNumber(2)),
+			args:        args{index: NewNumber(1)},
+			wantElement: Value{},
+			wantErr:     "index 1 is out of range [0, 1)",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotElement, gotErr := testCase.value.Element(testCase.args.index)
+
+			assert.Equal(test, testCase.wantElement, gotElement)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestValue_WithElement(test *testing.T) {
+	type args struct {
+		indexes []Value
+		element Value
+	}
+
+	testsCases := []struct {
+		name      string
+		value     Value
+		args      args
+		wantValue Value
+		wantErr   string
+	}{
+		{
+			name:  "success with an existing element",
+			value: NewArray(NewNumber(2), NewNumber(3)),
+			args: args{
+				indexes: []Value{NewNumber(1)},
+				element: NewNumber(5),
+			},
+			wantValue: NewArray(NewNumber(2), NewNumber(5)),
+			wantErr:   "",
+		},
+		{
+			name:  "success with growing",
+			value: NewArray(NewNumber(2)),
+			args: args{
+				indexes: []Value{NewNumber(2)},
+				element: NewNumber(5),
+			},
+			wantValue: NewArray(NewNumber(2), NewNumber(0), NewNumber(5)),
+			wantErr:   "",
+		},
+		{
+			name:  "success with few indexes",
+			value: NewArray(),
+			args: args{
+				indexes: []Value{NewNumber(1), NewNumber(0)},
+				element: NewNumber(5),
+			},
+			wantValue: NewArray(NewArray(), NewArray(NewNumber(5))),
+			wantErr:   "",
+		},
+		{
+			name:  "error with a non-array value",
+			value: NewArray(NewNumber(2)),
+			args: args{
+				indexes: []Value{NewNumber(0), NewNumber(0)},
+				element: NewNumber(5),
+			},
+			wantValue: Value{},
+			wantErr:   "unable to index the value of type number",
+		},
+		{
+			name:  "error with an incorrect index",
+			value: NewArray(NewNumber(2)),
+			args: args{
+				indexes: []Value{NewNumber(-1)},
+				element: NewNumber(5),
+			},
+			wantValue: Value{},
+			wantErr:   "index -1 isn't a non-negative integer",
+		},
+		{
+			name:  "error with a too large index",
+			value: NewArray(NewNumber(2)),
+			args: args{
+				indexes: []Value{NewNumber(math.Pow(2, 63))},
+				element: NewNumber(5),
+			},
+			wantValue: Value{},
+			wantErr:   "index 9.223372036854776e+18 is too large",
+		},
+		{
+			name:  "error with too much growth",
+			value: NewArray(NewNumber(2)),
+			args: args{
+				indexes: []Value{NewNumber(MaxArrayGrowth + 1)},
+				element: NewNumber(5),
+			},
+			wantValue: Value{},
+			wantErr: "index 65537 grows the array of length 1 " +
+				"by more than 65536 elements",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			copyOfValue := NewArray(
+				append([]Value(nil), testCase.value.Elements...)...,
+			)
+
+			gotValue, gotErr := testCase.value.WithElement(
+				testCase.args.indexes,
+				testCase.args.element,
+			)
+
+			assert.Equal(test, copyOfValue, testCase.value)
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// ConstantGroup contains values that can't be reassigned;
+// it's safe for concurrent reading only.
+type ConstantGroup map[string]Value
+
+// Variables returns a new group of variables with the same values,
+// e.g. for evaluating code that reads the constants.
+func (constants ConstantGroup) Variables() VariableGroup {
+	variables := VariableGroup{}
+	for name, value := range constants {
+		variables[name] = value
+	}
+
+	return variables
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantGroup_Variables(test *testing.T) {
+	testsCases := []struct {
+		name      string
+		constants ConstantGroup
+		want      VariableGroup
+	}{
+		{
+			name:      "nonempty",
+			constants: ConstantGroup{"one": NewNumber(23), "two": NewNumber(42)},
+			want:      VariableGroup{"one": NewNumber(23), "two": NewNumber(42)},
+		},
+		{
+			name:      "empty",
+			constants: ConstantGroup{},
+			want:      VariableGroup{},
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.constants.Variables()
+
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
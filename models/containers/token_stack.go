@@ -2,7 +2,7 @@ package containers
 
 import "github.com/irenicaa/go-calculator/v2/models"
 
-// TokenStack ...
+// TokenStack isn't safe for concurrent use.
 type TokenStack []models.Token
 
 // Push ...
@@ -0,0 +1,23 @@
+package containers
+
+import "github.com/irenicaa/go-calculator/v2/models"
+
+// ValueStack isn't safe for concurrent use.
+type ValueStack []models.Value
+
+// Push ...
+func (stack *ValueStack) Push(value models.Value) {
+	*stack = append(*stack, value)
+}
+
+// Pop ...
+func (stack *ValueStack) Pop() (models.Value, bool) {
+	if len(*stack) == 0 {
+		return models.Value{}, false
+	}
+
+	value := (*stack)[len(*stack)-1]
+	*stack = (*stack)[:len(*stack)-1]
+
+	return value, true
+}
@@ -0,0 +1,73 @@
+package containers
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueStack_Push(test *testing.T) {
+	type args struct {
+		value models.Value
+	}
+
+	testsCases := []struct {
+		name      string
+		stack     ValueStack
+		args      args
+		wantStack ValueStack
+	}{
+		{
+			name:  "nonempty",
+			stack: []models.Value{models.NewNumber(1), models.NewString("two")},
+			args:  args{value: models.NewNumber(3)},
+			wantStack: ValueStack{
+				models.NewNumber(1),
+				models.NewString("two"),
+				models.NewNumber(3),
+			},
+		},
+		{
+			name:      "empty",
+			stack:     []models.Value{},
+			args:      args{value: models.NewNumber(3)},
+			wantStack: ValueStack{models.NewNumber(3)},
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			testCase.stack.Push(testCase.args.value)
+			assert.Equal(test, testCase.wantStack, testCase.stack)
+		})
+	}
+}
+
+func TestValueStack_Pop(test *testing.T) {
+	testsCases := []struct {
+		name      string
+		stack     ValueStack
+		wantValue models.Value
+		wantOk    bool
+	}{
+		{
+			name:      "nonempty",
+			stack:     []models.Value{models.NewNumber(1), models.NewString("two")},
+			wantValue: models.NewString("two"),
+			wantOk:    true,
+		},
+		{
+			name:      "empty",
+			stack:     []models.Value{},
+			wantValue: models.Value{},
+			wantOk:    false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotOk := testCase.stack.Pop()
+			assert.Equal(test, testCase.wantValue, gotValue)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import "errors"
+
+// ExpressionEvaluator evaluates the commands of an expression;
+// the bindings shadow variables with the same names
+type ExpressionEvaluator func(
+	commands []Command,
+	bindings VariableGroup,
+) (Value, error)
+
+// Expression is an unevaluated argument of a function; it may be evaluated
+// concurrently if the variables and the functions it captures aren't changed
+type Expression struct {
+	Commands  []Command
+	evaluator ExpressionEvaluator
+}
+
+// NewExpression ...
+func NewExpression(commands []Command, evaluator ExpressionEvaluator) Value {
+	return Value{
+		Kind:       ExpressionValue,
+		Expression: &Expression{Commands: commands, evaluator: evaluator},
+	}
+}
+
+// Evaluate evaluates the expression in a local scope, so the bindings
+// don't affect variables outside of the expression
+func (expression Expression) Evaluate(bindings VariableGroup) (Value, error) {
+	if expression.evaluator == nil {
+		return Value{}, errors.New("expression has no evaluator")
+	}
+
+	return expression.evaluator(expression.Commands, bindings)
+}
+
+// VariableName returns the name if the expression consists
+// of a single variable
+func (expression Expression) VariableName() (string, bool) {
+	if len(expression.Commands) != 1 ||
+		expression.Commands[0].Kind != PushVariableCommand {
+		return "", false
+	}
+
+	return expression.Commands[0].Operand, true
+}
+
+// Evaluate returns the value itself, except that expressions are evaluated
+// without bindings; it allows functions with unevaluated arguments
+// to accept ordinary values too
+func (value Value) Evaluate() (Value, error) {
+	if value.Kind != ExpressionValue {
+		return value, nil
+	}
+
+	return value.Expression.Evaluate(nil)
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpression_VariableName(test *testing.T) {
+	testsCases := []struct {
+		name       string
+		expression Expression
+		wantName   string
+		wantOk     bool
+	}{
+		{
+			name: "variable",
+			expression: Expression{
+				Commands: []Command{{Kind: PushVariableCommand, Operand: "x"}},
+			},
+			wantName: "x",
+			wantOk:   true,
+		},
+		{
+			name: "number",
+			expression: Expression{
+				Commands: []Command{{Kind: PushNumberCommand, Operand: "23"}},
+			},
+			wantName: "",
+			wantOk:   false,
+		},
+		{
+			name: "few commands",
+			expression: Expression{
+				Commands: []Command{
+					{Kind: PushVariableCommand, Operand: "x"},
+					{Kind: PushVariableCommand, Operand: "y"},
+				},
+			},
+			wantName: "",
+			wantOk:   false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotName, gotOk := testCase.expression.VariableName()
+
+			assert.Equal(test, testCase.wantName, gotName)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
+
+func TestValue_Evaluate(test *testing.T) {
+	testsCases := []struct {
+		name      string
+		value     Value
+		wantValue Value
+		wantErr   string
+	}{
+		{
+			name:      "number",
+			value:     NewNumber(23),
+			wantValue: NewNumber(23),
+			wantErr:   "",
+		},
+		{
+			name: "expression (success)",
+			value: NewExpression(
+				[]Command{{Kind: PushVariableCommand, Operand: "x"}},
+				func(commands []Command, bindings VariableGroup) (Value, error) {
+					return NewNumber(float64(len(commands) + len(bindings))), nil
+				},
+			),
+			wantValue: NewNumber(1),
+			wantErr:   "",
+		},
+		{
+			name: "expression (error)",
+			value: NewExpression(
+				[]Command{{Kind: PushVariableCommand, Operand: "x"}},
+				func(commands []Command, bindings VariableGroup) (Value, error) {
+					return Value{}, iotest.ErrTimeout
+				},
+			),
+			wantValue: Value{},
+			wantErr:   iotest.ErrTimeout.Error(),
+		},
+		{
+			name: "expression without an evaluator",
+			value: Value{
+				Kind:       ExpressionValue,
+				Expression: &Expression{},
+			},
+			wantValue: Value{},
+			wantErr:   "expression has no evaluator",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotErr := testCase.value.Evaluate()
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
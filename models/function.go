@@ -1,23 +1,292 @@
 package models
 
-// Function ...
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoHandler is returned by calls of functions without handlers,
+// e.g. ones provided by FunctionNameGroup.
+var ErrNoHandler = errors.New("function has no handler")
+
+// Function describes a function of the calculator; its handlers may be
+// called from several goroutines, so they should be safe for concurrent use.
 type Function struct {
-	Arity   int // argument count
-	Handler func(arguments []float64) (float64, error)
+	Arity int // argument count
+	// if greater than the Arity field, the function accepts
+	// from Arity to MaxArity arguments
+	MaxArity int
+	// if true, the function accepts any count of arguments
+	// not less than the Arity field
+	Variadic bool
+	// if true, results of the function depend not only on its arguments,
+	// so they shouldn't be cached or precalculated
+	Impure bool
+	// count of the first arguments that are passed to the ValueHandler field
+	// unevaluated, i.e. as expressions
+	LazyArgumentCount int
+	// number of the unevaluated argument, counting from one, that names
+	// a variable bound by the function, e.g. 2 for sum(x ^ 2, x, 1, 3);
+	// zero means that there's no such argument
+	BoundVariableNumber int
+	// if positive, the argument names the bound variable only in calls
+	// with this argument count, e.g. 3 for solve(x ^ 2 - 2, x, 1),
+	// but not for solve(A, b)
+	BoundVariableArity int
+	Handler            func(arguments []float64) (float64, error)
+	// if specified, the Handler field also receives quantities
+	// as magnitudes in the base units, and this handler calculates
+	// the dimension of the result; dimensionless results are numbers
+	DimensionHandler func(
+		arguments []float64,
+		dimensions []Dimension,
+	) (Dimension, error)
+	// if specified, it's used instead of the Handler field
+	// and receives numbers and complex numbers; results
+	// with a zero imaginary part are returned as numbers;
+	// quantities are passed to the Handler field if it's specified
+	ComplexHandler func(arguments []complex128) (complex128, error)
+	// if specified, it's used instead of the other handlers
+	// and receives arguments of any kinds
+	ValueHandler func(arguments []Value) (Value, error)
+}
+
+// HasVariableArity checks that the argument count is taken from the call.
+func (function Function) HasVariableArity() bool {
+	return function.Variadic || function.MaxArity > function.Arity
+}
+
+// BoundVariableIndex returns the index of the argument that names
+// the variable bound by the function in a call with the argument count.
+func (function Function) BoundVariableIndex(argumentCount int) (int, bool) {
+	if function.BoundVariableNumber == 0 ||
+		(function.BoundVariableArity != 0 &&
+			argumentCount != function.BoundVariableArity) {
+		return 0, false
+	}
+
+	return function.BoundVariableNumber - 1, true
+}
+
+// CheckArgumentCount ...
+func (function Function) CheckArgumentCount(count int) error {
+	switch {
+	case function.Variadic && count < function.Arity:
+		return fmt.Errorf(
+			"%d is given, but at least %d are expected",
+			count,
+			function.Arity,
+		)
+	case !function.Variadic && function.MaxArity > function.Arity &&
+		(count < function.Arity || count > function.MaxArity):
+		return fmt.Errorf(
+			"%d is given, but from %d to %d are expected",
+			count,
+			function.Arity,
+			function.MaxArity,
+		)
+	case !function.HasVariableArity() && count != function.Arity:
+		return fmt.Errorf("%d is given, but %d are expected", count, function.Arity)
+	}
+
+	return nil
+}
+
+// Call ...
+func (function Function) Call(arguments []Value) (Value, error) {
+	if function.ValueHandler != nil {
+		return function.ValueHandler(arguments)
+	}
+
+	if function.ComplexHandler != nil &&
+		(function.Handler == nil || !containsQuantities(arguments)) {
+		return callElementWise(ComplexValue, arguments, function.callComplex)
+	}
+
+	if function.Handler == nil {
+		return Value{}, ErrNoHandler
+	}
+
+	if function.DimensionHandler != nil {
+		return callElementWise(
+			NumberValue,
+			arguments,
+			function.callNumber,
+			QuantityValue,
+		)
+	}
+
+	return callElementWise(NumberValue, arguments, function.callNumber)
+}
+
+func (function Function) callNumber(arguments []Value) (Value, error) {
+	numbers := make([]float64, 0, len(arguments))
+	for _, argument := range arguments {
+		numbers = append(numbers, argument.Number)
+	}
+
+	number, err := function.Handler(numbers)
+	if err != nil {
+		return Value{}, err
+	}
+	if function.DimensionHandler == nil {
+		return NewNumber(number), nil
+	}
+
+	dimensions := make([]Dimension, 0, len(arguments))
+	for _, argument := range arguments {
+		dimensions = append(dimensions, argument.Unit.Dimension)
+	}
+
+	dimension, err := function.DimensionHandler(numbers, dimensions)
+	if err != nil {
+		return Value{}, err
+	}
+	if dimension.IsZero() {
+		return NewNumber(number), nil
+	}
+
+	return NewQuantity(number, dimension), nil
 }
 
-// FunctionNameGroup ...
-type FunctionNameGroup map[string]struct{}
+func (function Function) callComplex(arguments []Value) (Value, error) {
+	numbers := make([]complex128, 0, len(arguments))
+	for _, argument := range arguments {
+		numbers = append(numbers, argument.ToComplex())
+	}
+
+	number, err := function.ComplexHandler(numbers)
+	if err != nil {
+		return Value{}, err
+	}
+	if imag(number) == 0 {
+		return NewNumber(real(number)), nil
+	}
+
+	return NewComplex(number), nil
+}
+
+// it applies the handler to elements of arrays with broadcasting of scalars;
+// numbers are always accepted as scalars along with the wanted kind
+// and the additional kinds
+func callElementWise(
+	wantedKind ValueKind,
+	arguments []Value,
+	handler func(arguments []Value) (Value, error),
+	additionalKinds ...ValueKind,
+) (Value, error) {
+	length := -1
+	for argumentIndex, argument := range arguments {
+		switch {
+		case argument.Kind == NumberValue, argument.Kind == wantedKind,
+			containsKind(additionalKinds, argument.Kind):
+		case argument.Kind == ArrayValue:
+			if length != -1 && length != len(argument.Elements) {
+				return Value{}, fmt.Errorf(
+					"array lengths are different: %d and %d",
+					length,
+					len(argument.Elements),
+				)
+			}
+
+			length = len(argument.Elements)
+		default:
+			return Value{}, TypeError{
+				ArgumentIndex: argumentIndex,
+				Kind:          argument.Kind,
+				WantedKind:    wantedKind,
+			}
+		}
+	}
+
+	if length == -1 {
+		return handler(arguments)
+	}
+
+	elements := make([]Value, 0, length)
+	for elementIndex := 0; elementIndex < length; elementIndex++ {
+		elementArguments := make([]Value, 0, len(arguments))
+		for _, argument := range arguments {
+			if argument.Kind == ArrayValue {
+				argument = argument.Elements[elementIndex]
+			}
+
+			elementArguments = append(elementArguments, argument)
+		}
+
+		element, err := callElementWise(
+			wantedKind,
+			elementArguments,
+			handler,
+			additionalKinds...,
+		)
+		if err != nil {
+			return Value{}, fmt.Errorf("element #%d: %w", elementIndex, err)
+		}
+
+		elements = append(elements, element)
+	}
+
+	return NewArray(elements...), nil
+}
+
+// it checks elements of arrays too
+func containsQuantities(values []Value) bool {
+	for _, value := range values {
+		if value.Kind == QuantityValue ||
+			(value.Kind == ArrayValue && containsQuantities(value.Elements)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsKind(kinds []ValueKind, kind ValueKind) bool {
+	for _, candidate := range kinds {
+		if candidate == kind {
+			return true
+		}
+	}
+
+	return false
+}
 
-// FunctionGroup ...
+// FunctionSignature contains the properties of a function
+// that are used by the translator
+type FunctionSignature struct {
+	LazyArgumentCount int
+}
+
+// FunctionNameGroup is safe for concurrent reading only.
+type FunctionNameGroup map[string]FunctionSignature
+
+// FunctionGroup is safe for concurrent reading only; its methods
+// don't change the group but return new ones.
 type FunctionGroup map[string]Function
 
 // Names ...
 func (functions FunctionGroup) Names() FunctionNameGroup {
 	functionsNames := FunctionNameGroup{}
-	for name := range functions {
-		functionsNames[name] = struct{}{}
+	for name, function := range functions {
+		functionsNames[name] = FunctionSignature{
+			LazyArgumentCount: function.LazyArgumentCount,
+		}
 	}
 
 	return functionsNames
 }
+
+// Merge returns a new group with functions of both groups;
+// functions of the other group replace ones with the same names
+func (functions FunctionGroup) Merge(other FunctionGroup) FunctionGroup {
+	mergedFunctions := FunctionGroup{}
+	for name, function := range functions {
+		mergedFunctions[name] = function
+	}
+	for name, function := range other {
+		mergedFunctions[name] = function
+	}
+
+	return mergedFunctions
+}
@@ -0,0 +1,92 @@
+package models
+
+import "strings"
+
+// FunctionProvider provides functions by their names to the translator
+// and the evaluator, e.g. dynamically from a registry of plugins;
+// it should be safe for concurrent use.
+//
+// FunctionGroup is its simplest implementation, and FunctionNameGroup
+// provides functions without handlers, so it's suitable
+// for the translator only.
+type FunctionProvider interface {
+	Function(name string) (function Function, ok bool)
+}
+
+// Function ...
+func (functions FunctionGroup) Function(name string) (Function, bool) {
+	function, ok := functions[name]
+	return function, ok
+}
+
+// Function returns a function without handlers, so its calls
+// return ErrNoHandler.
+func (functions FunctionNameGroup) Function(name string) (Function, bool) {
+	signature, ok := functions[name]
+	if !ok {
+		return Function{}, false
+	}
+
+	return Function{LazyArgumentCount: signature.LazyArgumentCount}, true
+}
+
+// FunctionProviderChain looks for a function in its providers one by one,
+// so the first providers shadow functions of the next ones.
+type FunctionProviderChain []FunctionProvider
+
+// Function ...
+func (providers FunctionProviderChain) Function(name string) (Function, bool) {
+	for _, provider := range providers {
+		if function, ok := provider.Function(name); ok {
+			return function, true
+		}
+	}
+
+	return Function{}, false
+}
+
+// PrefixedFunctionProvider provides functions of the inner provider
+// with the prefix, e.g. "geo_distance" for the prefix "geo_"
+// and the inner function "distance".
+type PrefixedFunctionProvider struct {
+	Prefix   string
+	Provider FunctionProvider
+}
+
+// Function ...
+func (provider PrefixedFunctionProvider) Function(name string) (Function, bool) {
+	if !strings.HasPrefix(name, provider.Prefix) {
+		return Function{}, false
+	}
+
+	return provider.Provider.Function(strings.TrimPrefix(name, provider.Prefix))
+}
+
+// AllowedFunctionProvider provides only the specified functions
+// of the inner provider.
+type AllowedFunctionProvider struct {
+	names    map[string]struct{}
+	provider FunctionProvider
+}
+
+// NewAllowedFunctionProvider ...
+func NewAllowedFunctionProvider(
+	provider FunctionProvider,
+	names ...string,
+) AllowedFunctionProvider {
+	allowedNames := map[string]struct{}{}
+	for _, name := range names {
+		allowedNames[name] = struct{}{}
+	}
+
+	return AllowedFunctionProvider{names: allowedNames, provider: provider}
+}
+
+// Function ...
+func (provider AllowedFunctionProvider) Function(name string) (Function, bool) {
+	if _, ok := provider.names[name]; !ok {
+		return Function{}, false
+	}
+
+	return provider.provider.Function(name)
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionProvider(test *testing.T) {
+	functions := FunctionGroup{
+		"add": {Arity: 2},
+		"sub": {Arity: 2},
+	}
+	otherFunctions := FunctionGroup{
+		"add": {Arity: 3},
+		"neg": {Arity: 1},
+	}
+
+	testsCases := []struct {
+		name         string
+		provider     FunctionProvider
+		functionName string
+		wantFunction Function
+		wantOk       bool
+	}{
+		{
+			name:         "function group (success)",
+			provider:     functions,
+			functionName: "add",
+			wantFunction: Function{Arity: 2},
+			wantOk:       true,
+		},
+		{
+			name:         "function group (error)",
+			provider:     functions,
+			functionName: "neg",
+			wantFunction: Function{},
+			wantOk:       false,
+		},
+		{
+			name:         "function name group",
+			provider:     FunctionNameGroup{"apply": {LazyArgumentCount: 1}},
+			functionName: "apply",
+			wantFunction: Function{LazyArgumentCount: 1},
+			wantOk:       true,
+		},
+		{
+			name:         "chain (shadowed function)",
+			provider:     FunctionProviderChain{functions, otherFunctions},
+			functionName: "add",
+			wantFunction: Function{Arity: 2},
+			wantOk:       true,
+		},
+		{
+			name:         "chain (next provider)",
+			provider:     FunctionProviderChain{functions, otherFunctions},
+			functionName: "neg",
+			wantFunction: Function{Arity: 1},
+			wantOk:       true,
+		},
+		{
+			name:         "chain (error)",
+			provider:     FunctionProviderChain{functions, otherFunctions},
+			functionName: "mul",
+			wantFunction: Function{},
+			wantOk:       false,
+		},
+		{
+			name: "prefixed provider (success)",
+			provider: PrefixedFunctionProvider{
+				Prefix:   "math_",
+				Provider: functions,
+			},
+			functionName: "math_sub",
+			wantFunction: Function{Arity: 2},
+			wantOk:       true,
+		},
+		{
+			name: "prefixed provider (error)",
+			provider: PrefixedFunctionProvider{
+				Prefix:   "math_",
+				Provider: functions,
+			},
+			functionName: "sub",
+			wantFunction: Function{},
+			wantOk:       false,
+		},
+		{
+			name:         "allowed provider (success)",
+			provider:     NewAllowedFunctionProvider(functions, "sub", "neg"),
+			functionName: "sub",
+			wantFunction: Function{Arity: 2},
+			wantOk:       true,
+		},
+		{
+			name:         "allowed provider (forbidden function)",
+			provider:     NewAllowedFunctionProvider(functions, "sub", "neg"),
+			functionName: "add",
+			wantFunction: Function{},
+			wantOk:       false,
+		},
+		{
+			name:         "allowed provider (missed function)",
+			provider:     NewAllowedFunctionProvider(functions, "sub", "neg"),
+			functionName: "neg",
+			wantFunction: Function{},
+			wantOk:       false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotFunction, gotOk := testCase.provider.Function(testCase.functionName)
+
+			assert.Equal(test, testCase.wantFunction, gotFunction)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
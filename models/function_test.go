@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,6 +31,19 @@ func TestFunctionGroup_Names(test *testing.T) {
 			},
 			want: FunctionNameGroup{"add": {}, "sub": {}},
 		},
+		{
+			name: "with unevaluated arguments",
+			functions: FunctionGroup{
+				"apply": {
+					Arity:             1,
+					LazyArgumentCount: 1,
+					ValueHandler: func(arguments []Value) (Value, error) {
+						return arguments[0].Evaluate()
+					},
+				},
+			},
+			want: FunctionNameGroup{"apply": {LazyArgumentCount: 1}},
+		},
 		{
 			name:      "empty",
 			functions: FunctionGroup{},
@@ -44,3 +58,413 @@ func TestFunctionGroup_Names(test *testing.T) {
 		})
 	}
 }
+
+func TestFunctionGroup_Merge(test *testing.T) {
+	functions := FunctionGroup{"one": {Arity: 1}, "two": {Arity: 2}}
+
+	got := functions.Merge(FunctionGroup{"two": {Arity: 3}, "three": {Arity: 4}})
+
+	assert.Equal(test, FunctionGroup{
+		"one":   {Arity: 1},
+		"two":   {Arity: 3},
+		"three": {Arity: 4},
+	}, got)
+	assert.Equal(test, FunctionGroup{"one": {Arity: 1}, "two": {Arity: 2}}, functions)
+}
+
+func TestFunction_CheckArgumentCount(test *testing.T) {
+	type args struct {
+		count int
+	}
+
+	testsCases := []struct {
+		name     string
+		function Function
+		args     args
+		wantErr  string
+	}{
+		{
+			name:     "success with the fixed arity",
+			function: Function{Arity: 2},
+			args:     args{count: 2},
+			wantErr:  "",
+		},
+		{
+			name:     "success with optional arguments",
+			function: Function{Arity: 1, MaxArity: 2},
+			args:     args{count: 2},
+			wantErr:  "",
+		},
+		{
+			name:     "success with the variadic function",
+			function: Function{Arity: 1, Variadic: true},
+			args:     args{count: 5},
+			wantErr:  "",
+		},
+		{
+			name:     "error with the fixed arity",
+			function: Function{Arity: 2},
+			args:     args{count: 3},
+			wantErr:  "3 is given, but 2 are expected",
+		},
+		{
+			name:     "error with optional arguments",
+			function: Function{Arity: 1, MaxArity: 2},
+			args:     args{count: 0},
+			wantErr:  "0 is given, but from 1 to 2 are expected",
+		},
+		{
+			name:     "error with the variadic function",
+			function: Function{Arity: 1, Variadic: true},
+			args:     args{count: 0},
+			wantErr:  "0 is given, but at least 1 are expected",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotErr := testCase.function.CheckArgumentCount(testCase.args.count)
+
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunction_Call(test *testing.T) {
+	type args struct {
+		arguments []Value
+	}
+
+	testsCases := []struct {
+		name      string
+		function  Function
+		args      args
+		wantValue Value
+		wantErr   string
+	}{
+		{
+			name: "success with the handler",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args:      args{arguments: []Value{NewNumber(2), NewNumber(3)}},
+			wantValue: NewNumber(5),
+			wantErr:   "",
+		},
+		{
+			name: "success with the value handler",
+			function: Function{
+				Arity: 2,
+				ValueHandler: func(arguments []Value) (Value, error) {
+					return NewString(arguments[0].Text + arguments[1].String()), nil
+				},
+			},
+			args:      args{arguments: []Value{NewString("test"), NewNumber(2)}},
+			wantValue: NewString("test2"),
+			wantErr:   "",
+		},
+		{
+			name: "success with broadcasting",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewArray(NewNumber(2), NewArray(NewNumber(3), NewNumber(4))),
+					NewNumber(10),
+				},
+			},
+			wantValue: NewArray(
+				NewNumber(12),
+				NewArray(NewNumber(13), NewNumber(14)),
+			),
+			wantErr: "",
+		},
+		{
+			name: "success with element-wise arrays",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] * arguments[1], nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewArray(NewNumber(2), NewNumber(3)),
+					NewArray(NewNumber(4), NewNumber(5)),
+				},
+			},
+			wantValue: NewArray(NewNumber(8), NewNumber(15)),
+			wantErr:   "",
+		},
+		{
+			name: "error with array lengths",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewArray(NewNumber(2), NewNumber(3)),
+					NewArray(NewNumber(4)),
+				},
+			},
+			wantValue: Value{},
+			wantErr:   "array lengths are different: 2 and 1",
+		},
+		{
+			name: "error with an element",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewArray(NewNumber(2), NewString("test")),
+					NewNumber(3),
+				},
+			},
+			wantValue: Value{},
+			wantErr: "element #1: " +
+				"argument #0 has type string, but number is expected",
+		},
+		{
+			name: "success with the complex handler",
+			function: Function{
+				Arity: 2,
+				ComplexHandler: func(arguments []complex128) (complex128, error) {
+					return arguments[0] * arguments[1], nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewComplex(2i),
+					NewArray(NewNumber(3), NewComplex(4i)),
+				},
+			},
+			wantValue: NewArray(NewComplex(6i), NewNumber(-8)),
+			wantErr:   "",
+		},
+		{
+			name: "error with the argument kind for the complex handler",
+			function: Function{
+				Arity: 2,
+				ComplexHandler: func(arguments []complex128) (complex128, error) {
+					return arguments[0] * arguments[1], nil
+				},
+			},
+			args:      args{arguments: []Value{NewNumber(2), NewString("test")}},
+			wantValue: Value{},
+			wantErr:   "argument #1 has type string, but complex is expected",
+		},
+		{
+			name: "error with the complex handler",
+			function: Function{
+				Arity: 2,
+				ComplexHandler: func(arguments []complex128) (complex128, error) {
+					return 0, iotest.ErrTimeout
+				},
+			},
+			args:      args{arguments: []Value{NewNumber(2), NewComplex(3i)}},
+			wantValue: Value{},
+			wantErr:   iotest.ErrTimeout.Error(),
+		},
+		{
+			name: "error with the complex argument for the handler",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args:      args{arguments: []Value{NewNumber(2), NewComplex(3i)}},
+			wantValue: Value{},
+			wantErr:   "argument #1 has type complex, but number is expected",
+		},
+		{
+			name: "success with the dimension handler",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] * arguments[1], nil
+				},
+				DimensionHandler: func(
+					arguments []float64,
+					dimensions []Dimension,
+				) (Dimension, error) {
+					return dimensions[0].Multiply(dimensions[1]), nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewQuantity(2, NewBaseDimension(LengthDimension)),
+					NewArray(
+						NewNumber(3),
+						NewQuantity(4, Dimension{LengthDimension: -1}),
+					),
+				},
+			},
+			wantValue: NewArray(
+				NewQuantity(6, NewBaseDimension(LengthDimension)),
+				NewNumber(8),
+			),
+			wantErr: "",
+		},
+		{
+			name: "error with the dimension handler",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+				DimensionHandler: func(
+					arguments []float64,
+					dimensions []Dimension,
+				) (Dimension, error) {
+					return Dimension{}, iotest.ErrTimeout
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewQuantity(2, NewBaseDimension(LengthDimension)),
+					NewNumber(3),
+				},
+			},
+			wantValue: Value{},
+			wantErr:   iotest.ErrTimeout.Error(),
+		},
+		{
+			name: "error with the quantity argument for the handler",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args: args{
+				arguments: []Value{
+					NewQuantity(2, NewBaseDimension(LengthDimension)),
+					NewNumber(3),
+				},
+			},
+			wantValue: Value{},
+			wantErr:   "argument #0 has type quantity, but number is expected",
+		},
+		{
+			name: "error with the argument kind",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return arguments[0] + arguments[1], nil
+				},
+			},
+			args:      args{arguments: []Value{NewString("test"), NewNumber(2)}},
+			wantValue: Value{},
+			wantErr:   "argument #0 has type string, but number is expected",
+		},
+		{
+			name: "error with the handler",
+			function: Function{
+				Arity: 2,
+				Handler: func(arguments []float64) (float64, error) {
+					return 0, iotest.ErrTimeout
+				},
+			},
+			args:      args{arguments: []Value{NewNumber(2), NewNumber(3)}},
+			wantValue: Value{},
+			wantErr:   iotest.ErrTimeout.Error(),
+		},
+		{
+			name:      "error without handlers",
+			function:  Function{Arity: 2},
+			args:      args{arguments: []Value{NewNumber(2), NewNumber(3)}},
+			wantValue: Value{},
+			wantErr:   "function has no handler",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotErr := testCase.function.Call(testCase.args.arguments)
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunction_BoundVariableIndex(test *testing.T) {
+	testsCases := []struct {
+		name          string
+		function      Function
+		argumentCount int
+		wantIndex     int
+		wantOk        bool
+	}{
+		{
+			name:          "without the bound variable",
+			function:      Function{Arity: 2, LazyArgumentCount: 2},
+			argumentCount: 2,
+			wantIndex:     0,
+			wantOk:        false,
+		},
+		{
+			name:          "with the bound variable",
+			function:      Function{Arity: 4, LazyArgumentCount: 2, BoundVariableNumber: 2},
+			argumentCount: 4,
+			wantIndex:     1,
+			wantOk:        true,
+		},
+		{
+			name: "with the bound variable and the suitable arity",
+			function: Function{
+				Arity:               2,
+				MaxArity:            3,
+				LazyArgumentCount:   2,
+				BoundVariableNumber: 2,
+				BoundVariableArity:  3,
+			},
+			argumentCount: 3,
+			wantIndex:     1,
+			wantOk:        true,
+		},
+		{
+			name: "with the bound variable and the unsuitable arity",
+			function: Function{
+				Arity:               2,
+				MaxArity:            3,
+				LazyArgumentCount:   2,
+				BoundVariableNumber: 2,
+				BoundVariableArity:  3,
+			},
+			argumentCount: 2,
+			wantIndex:     0,
+			wantOk:        false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotIndex, gotOk :=
+				testCase.function.BoundVariableIndex(testCase.argumentCount)
+
+			assert.Equal(test, testCase.wantIndex, gotIndex)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
@@ -1,6 +1,6 @@
 package models
 
-// Token ...
+// Token is a plain value, as well as Command.
 type Token struct {
 	Kind  TokenKind
 	Value string
@@ -14,10 +14,24 @@ const (
 	PushNumberCommand CommandKind = iota
 	PushVariableCommand
 	CallFunctionCommand
+	PushStringCommand
+	IndexCommand
+	MakeArrayCommand
+	// commands between these ones aren't evaluated, but they're passed
+	// to a function as an expression
+	StartExpressionCommand
+	EndExpressionCommand
+	// it pushes a variable or, if it's missed, a unit; it's used only
+	// for an identifier right after a number, e.g. "min" in "20 min",
+	// where the unit syntax is unambiguous
+	PushUnitCommand
 )
 
-// Command ...
+// Command is an instruction of the evaluator;
+// commands aren't changed after translation, so they may be shared.
 type Command struct {
 	Kind    CommandKind
 	Operand string
+	// it's specified for calls of functions with parentheses only
+	ArgumentCount int
 }
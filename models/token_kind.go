@@ -18,6 +18,12 @@ const (
 	LeftParenthesisToken
 	RightParenthesisToken
 	CommaToken
+	StringToken
+	LeftBracketToken
+	RightBracketToken
+	// the tokenizer doesn't produce it, the translator inserts it
+	// between a number and a following identifier, e.g. in "3 km"
+	ImplicitMultiplicationToken
 )
 
 // ParseTokenKind ...
@@ -41,6 +47,10 @@ func ParseTokenKind(symbol rune) (TokenKind, error) {
 		return RightParenthesisToken, nil
 	case ',':
 		return CommaToken, nil
+	case '[':
+		return LeftBracketToken, nil
+	case ']':
+		return RightBracketToken, nil
 	default:
 		return 0, fmt.Errorf("unknown symbol %q", symbol)
 	}
@@ -51,12 +61,17 @@ func (kind TokenKind) IsParenthesis() bool {
 	return kind == LeftParenthesisToken || kind == RightParenthesisToken
 }
 
+// IsBracket ...
+func (kind TokenKind) IsBracket() bool {
+	return kind == LeftBracketToken || kind == RightBracketToken
+}
+
 // IsOperator ...
 func (kind TokenKind) IsOperator() bool {
 	switch kind {
 	case PlusToken, MinusToken,
 		AsteriskToken, SlashToken, PercentToken,
-		ExponentiationToken:
+		ImplicitMultiplicationToken, ExponentiationToken:
 		return true
 	default:
 		return false
@@ -70,8 +85,10 @@ func (kind TokenKind) Precedence() int {
 		return 1
 	case AsteriskToken, SlashToken, PercentToken:
 		return 2
-	case ExponentiationToken:
+	case ImplicitMultiplicationToken:
 		return 3
+	case ExponentiationToken:
+		return 4
 	default:
 		return 0
 	}
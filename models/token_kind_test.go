@@ -71,6 +71,18 @@ func TestParseTokenKind(test *testing.T) {
 			wantTokenKind: CommaToken,
 			wantErr:       "",
 		},
+		{
+			name:          "left bracket",
+			args:          args{symbol: '['},
+			wantTokenKind: LeftBracketToken,
+			wantErr:       "",
+		},
+		{
+			name:          "right bracket",
+			args:          args{symbol: ']'},
+			wantTokenKind: RightBracketToken,
+			wantErr:       "",
+		},
 		{
 			name:          "error",
 			args:          args{symbol: '!'},
@@ -123,6 +135,11 @@ func TestTokenKind_IsOperator(test *testing.T) {
 			kind:   PercentToken,
 			wantOk: true,
 		},
+		{
+			name:   "implicit multiplication",
+			kind:   ImplicitMultiplicationToken,
+			wantOk: true,
+		},
 		{
 			name:   "exponentiation",
 			kind:   ExponentiationToken,
@@ -174,6 +191,36 @@ func TestTokenKind_IsParenthesis(test *testing.T) {
 	}
 }
 
+func TestTokenKind_IsBracket(test *testing.T) {
+	testsCases := []struct {
+		name   string
+		kind   TokenKind
+		wantOk bool
+	}{
+		{
+			name:   "left bracket",
+			kind:   LeftBracketToken,
+			wantOk: true,
+		},
+		{
+			name:   "right bracket",
+			kind:   RightBracketToken,
+			wantOk: true,
+		},
+		{
+			name:   "not bracket",
+			kind:   LeftParenthesisToken,
+			wantOk: false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotOk := testCase.kind.IsBracket()
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
+
 func TestTokenKind_Precedence(test *testing.T) {
 	testsCases := []struct {
 		name           string
@@ -205,10 +252,15 @@ func TestTokenKind_Precedence(test *testing.T) {
 			kind:           PercentToken,
 			wantPrecedence: 2,
 		},
+		{
+			name:           "implicit multiplication",
+			kind:           ImplicitMultiplicationToken,
+			wantPrecedence: 3,
+		},
 		{
 			name:           "exponentiation",
 			kind:           ExponentiationToken,
-			wantPrecedence: 3,
+			wantPrecedence: 4,
 		},
 		{
 			name:           "not operator",
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BaseDimension ...
+type BaseDimension int
+
+// ...
+const (
+	LengthDimension BaseDimension = iota
+	MassDimension
+	TimeDimension
+	CurrentDimension
+	TemperatureDimension
+	AmountDimension
+	LuminousIntensityDimension
+	InformationDimension
+
+	baseDimensionCount
+)
+
+// symbols of the base units of the base dimensions
+var baseUnitSymbols = [baseDimensionCount]string{
+	LengthDimension:            "m",
+	MassDimension:              "kg",
+	TimeDimension:              "s",
+	CurrentDimension:           "A",
+	TemperatureDimension:       "K",
+	AmountDimension:            "mol",
+	LuminousIntensityDimension: "cd",
+	InformationDimension:       "B",
+}
+
+// Dimension contains exponents of the base dimensions.
+type Dimension [baseDimensionCount]int
+
+// NewBaseDimension ...
+func NewBaseDimension(baseDimension BaseDimension) Dimension {
+	dimension := Dimension{}
+	dimension[baseDimension] = 1
+
+	return dimension
+}
+
+// IsZero checks that the dimension is dimensionless.
+func (dimension Dimension) IsZero() bool {
+	return dimension == Dimension{}
+}
+
+// Multiply ...
+func (dimension Dimension) Multiply(other Dimension) Dimension {
+	for index := range dimension {
+		dimension[index] += other[index]
+	}
+
+	return dimension
+}
+
+// Divide ...
+func (dimension Dimension) Divide(other Dimension) Dimension {
+	for index := range dimension {
+		dimension[index] -= other[index]
+	}
+
+	return dimension
+}
+
+// Power ...
+func (dimension Dimension) Power(exponent float64) (Dimension, error) {
+	result := Dimension{}
+	for index, baseExponent := range dimension {
+		newExponent := float64(baseExponent) * exponent
+		if newExponent != math.Trunc(newExponent) {
+			return Dimension{}, fmt.Errorf(
+				"unable to raise the dimension %s to the power %g",
+				dimension,
+				exponent,
+			)
+		}
+
+		result[index] = int(newExponent)
+	}
+
+	return result, nil
+}
+
+// String returns the dimension in the base units, e.g. "kg*m/s^2".
+func (dimension Dimension) String() string {
+	if dimension.IsZero() {
+		return "1"
+	}
+
+	numerator, denominator := []string{}, []string{}
+	for index, exponent := range dimension {
+		switch {
+		case exponent > 0:
+			numerator = append(numerator, formatPower(index, exponent))
+		case exponent < 0:
+			denominator = append(denominator, formatPower(index, -exponent))
+		}
+	}
+	if len(numerator) == 0 {
+		powers := []string{}
+		for index, exponent := range dimension {
+			if exponent < 0 {
+				powers = append(powers, formatPower(index, exponent))
+			}
+		}
+
+		return strings.Join(powers, "*")
+	}
+
+	text := strings.Join(numerator, "*")
+	for _, unit := range denominator {
+		text += "/" + unit
+	}
+
+	return text
+}
+
+// Unit is a plain value, so it's safe to copy and share.
+type Unit struct {
+	Name      string
+	Factor    float64 // the value of the unit in the base units
+	Dimension Dimension
+}
+
+// NewQuantity creates a quantity with the magnitude in the base units.
+func NewQuantity(number float64, dimension Dimension) Value {
+	return Value{
+		Kind:   QuantityValue,
+		Number: number,
+		Unit:   Unit{Factor: 1, Dimension: dimension},
+	}
+}
+
+// WithUnit converts numbers and quantities (including ones in arrays)
+// to the specified unit; the unit is used for representation only.
+func (value Value) WithUnit(unit Unit) (Value, error) {
+	switch value.Kind {
+	case NumberValue, QuantityValue:
+		if value.Unit.Dimension != unit.Dimension {
+			return Value{}, fmt.Errorf(
+				"dimensions are different: %s and %s",
+				value.Unit.Dimension,
+				unit.Dimension,
+			)
+		}
+
+		return Value{Kind: QuantityValue, Number: value.Number, Unit: unit}, nil
+	case ArrayValue:
+		elements := make([]Value, 0, len(value.Elements))
+		for elementIndex, element := range value.Elements {
+			convertedElement, err := element.WithUnit(unit)
+			if err != nil {
+				return Value{}, fmt.Errorf("element #%d: %w", elementIndex, err)
+			}
+
+			elements = append(elements, convertedElement)
+		}
+
+		return NewArray(elements...), nil
+	default:
+		return Value{}, fmt.Errorf("unable to convert the value of type %s", value.Kind)
+	}
+}
+
+func (value Value) formatQuantity() string {
+	if value.Unit.Name == "" {
+		number := strconv.FormatFloat(value.Number, 'g', -1, 64)
+		return number + " " + value.Unit.Dimension.String()
+	}
+
+	// the precision hides rounding errors of the conversion
+	number := value.Number / value.Unit.Factor
+	return strconv.FormatFloat(number, 'g', 15, 64) + " " + value.Unit.Name
+}
+
+func formatPower(baseDimension int, exponent int) string {
+	symbol := baseUnitSymbols[baseDimension]
+	if exponent == 1 {
+		return symbol
+	}
+
+	return symbol + "^" + strconv.Itoa(exponent)
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDimension_String(test *testing.T) {
+	testsCases := []struct {
+		name      string
+		dimension Dimension
+		want      string
+	}{
+		{
+			name:      "dimensionless",
+			dimension: Dimension{},
+			want:      "1",
+		},
+		{
+			name:      "base dimension",
+			dimension: NewBaseDimension(LengthDimension),
+			want:      "m",
+		},
+		{
+			name: "numerator and denominator",
+			dimension: Dimension{
+				LengthDimension:  2,
+				MassDimension:    1,
+				TimeDimension:    -2,
+				CurrentDimension: -1,
+			},
+			want: "m^2*kg/s^2/A",
+		},
+		{
+			name:      "denominator only",
+			dimension: Dimension{TimeDimension: -1, InformationDimension: -2},
+			want:      "s^-1*B^-2",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.dimension.String()
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
+
+func TestDimension_Multiply(test *testing.T) {
+	dimension := Dimension{LengthDimension: 1, TimeDimension: -1}
+
+	got := dimension.Multiply(Dimension{LengthDimension: 2, TimeDimension: 1})
+
+	assert.Equal(test, Dimension{LengthDimension: 3}, got)
+}
+
+func TestDimension_Divide(test *testing.T) {
+	dimension := Dimension{LengthDimension: 1, TimeDimension: -1}
+
+	got := dimension.Divide(Dimension{LengthDimension: 2, TimeDimension: 1})
+
+	assert.Equal(test, Dimension{LengthDimension: -1, TimeDimension: -2}, got)
+}
+
+func TestDimension_Power(test *testing.T) {
+	type args struct {
+		exponent float64
+	}
+
+	testsCases := []struct {
+		name          string
+		dimension     Dimension
+		args          args
+		wantDimension Dimension
+		wantErr       string
+	}{
+		{
+			name:          "success with an integer",
+			dimension:     Dimension{LengthDimension: 1, TimeDimension: -1},
+			args:          args{exponent: -2},
+			wantDimension: Dimension{LengthDimension: -2, TimeDimension: 2},
+			wantErr:       "",
+		},
+		{
+			name:          "success with a fraction",
+			dimension:     Dimension{LengthDimension: 2, TimeDimension: -4},
+			args:          args{exponent: 0.5},
+			wantDimension: Dimension{LengthDimension: 1, TimeDimension: -2},
+			wantErr:       "",
+		},
+		{
+			name:          "error",
+			dimension:     Dimension{LengthDimension: 2, TimeDimension: -1},
+			args:          args{exponent: 0.5},
+			wantDimension: Dimension{},
+			wantErr:       "unable to raise the dimension m^2/s to the power 0.5",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotDimension, gotErr := testCase.dimension.Power(testCase.args.exponent)
+
+			assert.Equal(test, testCase.wantDimension, gotDimension)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestValue_WithUnit(test *testing.T) {
+	kilometersPerHour := Unit{
+		Name:      "km/h",
+		Factor:    1000.0 / 3600,
+		Dimension: Dimension{LengthDimension: 1, TimeDimension: -1},
+	}
+
+	type args struct {
+		unit Unit
+	}
+
+	testsCases := []struct {
+		name       string
+		value      Value
+		args       args
+		wantValue  Value
+		wantString string
+		wantErr    string
+	}{
+		{
+			name:  "success with a quantity",
+			value: NewQuantity(2.5, kilometersPerHour.Dimension),
+			args:  args{unit: kilometersPerHour},
+			wantValue: Value{
+				Kind:   QuantityValue,
+				Number: 2.5,
+				Unit:   kilometersPerHour,
+			},
+			wantString: "9 km/h",
+			wantErr:    "",
+		},
+		{
+			name:  "success with a number",
+			value: NewNumber(0.5),
+			args:  args{unit: Unit{Name: "%", Factor: 0.01}},
+			wantValue: Value{
+				Kind:   QuantityValue,
+				Number: 0.5,
+				Unit:   Unit{Name: "%", Factor: 0.01},
+			},
+			wantString: "50 %",
+			wantErr:    "",
+		},
+		{
+			name: "success with an array",
+			value: NewArray(
+				NewQuantity(2.5, kilometersPerHour.Dimension),
+				NewQuantity(5, kilometersPerHour.Dimension),
+			),
+			args: args{unit: kilometersPerHour},
+			wantValue: NewArray(
+				Value{Kind: QuantityValue, Number: 2.5, Unit: kilometersPerHour},
+				Value{Kind: QuantityValue, Number: 5, Unit: kilometersPerHour},
+			),
+			wantString: "[9 km/h, 18 km/h]",
+			wantErr:    "",
+		},
+		{
+			name:       "error with dimensions",
+			value:      NewQuantity(2.5, NewBaseDimension(LengthDimension)),
+			args:       args{unit: kilometersPerHour},
+			wantValue:  Value{},
+			wantString: "",
+			wantErr:    "dimensions are different: m and m/s",
+		},
+		{
+			name:       "error with an element",
+			value:      NewArray(NewString("test")),
+			args:       args{unit: kilometersPerHour},
+			wantValue:  Value{},
+			wantString: "",
+			wantErr:    "element #0: unable to convert the value of type string",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotValue, gotErr := testCase.value.WithUnit(testCase.args.unit)
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.Equal(test, testCase.wantString, gotValue.String())
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValueKind ...
+type ValueKind int
+
+// ...
+const (
+	NumberValue ValueKind = iota
+	StringValue
+	ArrayValue
+	ComplexValue
+	QuantityValue
+	ExpressionValue
+)
+
+// String ...
+func (kind ValueKind) String() string {
+	switch kind {
+	case NumberValue:
+		return "number"
+	case StringValue:
+		return "string"
+	case ArrayValue:
+		return "array"
+	case ComplexValue:
+		return "complex"
+	case QuantityValue:
+		return "quantity"
+	case ExpressionValue:
+		return "expression"
+	default:
+		return fmt.Sprintf("ValueKind(%d)", int(kind))
+	}
+}
+
+// Value is treated as immutable: its methods return new values
+// instead of changing elements in place, so values may be shared
+// between goroutines.
+type Value struct {
+	Kind     ValueKind
+	Number   float64
+	Text     string
+	Elements []Value
+	Complex  complex128
+	Unit     Unit // it's used by quantities
+	// it's used by unevaluated arguments of functions
+	Expression *Expression
+}
+
+// NewNumber ...
+func NewNumber(number float64) Value {
+	return Value{Kind: NumberValue, Number: number}
+}
+
+// NewString ...
+func NewString(text string) Value {
+	return Value{Kind: StringValue, Text: text}
+}
+
+// NewComplex ...
+func NewComplex(number complex128) Value {
+	return Value{Kind: ComplexValue, Complex: number}
+}
+
+// ToComplex converts numbers and complex numbers to the complex128 type;
+// it returns zero for other kinds
+func (value Value) ToComplex() complex128 {
+	switch value.Kind {
+	case NumberValue:
+		return complex(value.Number, 0)
+	case ComplexValue:
+		return value.Complex
+	default:
+		return 0
+	}
+}
+
+// String ...
+func (value Value) String() string {
+	switch value.Kind {
+	case NumberValue:
+		return strconv.FormatFloat(value.Number, 'g', -1, 64)
+	case StringValue:
+		return value.Text
+	case ArrayValue:
+		elements := make([]string, 0, len(value.Elements))
+		for _, element := range value.Elements {
+			if element.Kind == StringValue {
+				elements = append(elements, strconv.Quote(element.Text))
+				continue
+			}
+
+			elements = append(elements, element.String())
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+	case ComplexValue:
+		text := strconv.FormatComplex(value.Complex, 'g', -1, 128)
+		return strings.Trim(text, "()")
+	case QuantityValue:
+		return value.formatQuantity()
+	default:
+		return fmt.Sprintf("<%s>", value.Kind)
+	}
+}
+
+// TypeError is a plain value describing an argument of a wrong type.
+type TypeError struct {
+	ArgumentIndex int
+	Kind          ValueKind
+	WantedKind    ValueKind
+}
+
+// Error ...
+func (err TypeError) Error() string {
+	return fmt.Sprintf(
+		"argument #%d has type %s, but %s is expected",
+		err.ArgumentIndex,
+		err.Kind,
+		err.WantedKind,
+	)
+}
+
+// CheckArgumentKinds ...
+func CheckArgumentKinds(arguments []Value, kinds ...ValueKind) error {
+	for argumentIndex, kind := range kinds {
+		if arguments[argumentIndex].Kind != kind {
+			return TypeError{
+				ArgumentIndex: argumentIndex,
+				Kind:          arguments[argumentIndex].Kind,
+				WantedKind:    kind,
+			}
+		}
+	}
+
+	return nil
+}
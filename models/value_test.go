@@ -0,0 +1,179 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueKind_String(test *testing.T) {
+	testsCases := []struct {
+		name string
+		kind ValueKind
+		want string
+	}{
+		{
+			name: "number",
+			kind: NumberValue,
+			want: "number",
+		},
+		{
+			name: "string",
+			kind: StringValue,
+			want: "string",
+		},
+		{
+			name: "array",
+			kind: ArrayValue,
+			want: "array",
+		},
+		{
+			name: "complex",
+			kind: ComplexValue,
+			want: "complex",
+		},
+		{
+			name: "quantity",
+			kind: QuantityValue,
+			want: "quantity",
+		},
+		{
+			name: "expression",
+			kind: ExpressionValue,
+			want: "expression",
+		},
+		{
+			name: "unknown",
+			kind: ValueKind(100),
+			want: "ValueKind(100)",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.kind.String()
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
+
+func TestValue_String(test *testing.T) {
+	testsCases := []struct {
+		name  string
+		value Value
+		want  string
+	}{
+		{
+			name:  "number",
+			value: NewNumber(2.5),
+			want:  "2.5",
+		},
+		{
+			name:  "number with an exponent",
+			value: NewNumber(2.5e100),
+			want:  "2.5e+100",
+		},
+		{
+			name:  "string",
+			value: NewString("test"),
+			want:  "test",
+		},
+		{
+			name: "array",
+			value: NewArray(
+				NewNumber(2),
+				NewString("test"),
+				NewArray(NewNumber(3), NewNumber(4)),
+			),
+			want: `[2, "test", [3, 4]]`,
+		},
+		{
+			name:  "complex",
+			value: NewComplex(3 - 4i),
+			want:  "3-4i",
+		},
+		{
+			name:  "quantity",
+			value: NewQuantity(2.5, Dimension{LengthDimension: 1, TimeDimension: -1}),
+			want:  "2.5 m/s",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.value.String()
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
+
+func TestValue_ToComplex(test *testing.T) {
+	testsCases := []struct {
+		name  string
+		value Value
+		want  complex128
+	}{
+		{
+			name:  "number",
+			value: NewNumber(2.5),
+			want:  2.5,
+		},
+		{
+			name:  "complex",
+			value: NewComplex(3 - 4i),
+			want:  3 - 4i,
+		},
+		{
+			name:  "string",
+			value: NewString("test"),
+			want:  0,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.value.ToComplex()
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
+
+func TestCheckArgumentKinds(test *testing.T) {
+	type args struct {
+		arguments []Value
+		kinds     []ValueKind
+	}
+
+	testsCases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success",
+			args: args{
+				arguments: []Value{NewNumber(2), NewString("test")},
+				kinds:     []ValueKind{NumberValue, StringValue},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error",
+			args: args{
+				arguments: []Value{NewNumber(2), NewNumber(3)},
+				kinds:     []ValueKind{NumberValue, StringValue},
+			},
+			wantErr: TypeError{
+				ArgumentIndex: 1,
+				Kind:          NumberValue,
+				WantedKind:    StringValue,
+			},
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotErr := CheckArgumentKinds(
+				testCase.args.arguments,
+				testCase.args.kinds...,
+			)
+			assert.Equal(test, testCase.wantErr, gotErr)
+		})
+	}
+}
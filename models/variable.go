@@ -1,7 +1,9 @@
 package models
 
-// VariableGroup ...
-type VariableGroup map[string]float64
+// VariableGroup is safe for concurrent reading only; use a copy
+// or a lock to change it while it's read by other goroutines,
+// see also Interpreter.
+type VariableGroup map[string]Value
 
 // Copy ...
 func (variables VariableGroup) Copy() VariableGroup {
@@ -12,3 +14,14 @@ func (variables VariableGroup) Copy() VariableGroup {
 
 	return copyOfVariables
 }
+
+// Merge returns a new group with variables of both groups;
+// variables of the other group replace ones with the same names
+func (variables VariableGroup) Merge(other VariableGroup) VariableGroup {
+	mergedVariables := variables.Copy()
+	for name, value := range other {
+		mergedVariables[name] = value
+	}
+
+	return mergedVariables
+}
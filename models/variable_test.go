@@ -14,8 +14,8 @@ func TestVariableGroup_Copy(test *testing.T) {
 	}{
 		{
 			name:      "nonempty",
-			variables: VariableGroup{"one": 23, "two": 42},
-			want:      VariableGroup{"one": 23, "two": 42},
+			variables: VariableGroup{"one": NewNumber(23), "two": NewNumber(42)},
+			want:      VariableGroup{"one": NewNumber(23), "two": NewNumber(42)},
 		},
 		{
 			name:      "empty",
@@ -33,16 +33,35 @@ func TestVariableGroup_Copy(test *testing.T) {
 }
 
 func TestVariableGroup_Copy_withModifiedCopy(test *testing.T) {
-	variables := VariableGroup{"one": 5, "two": 12}
+	variables := VariableGroup{"one": NewNumber(5), "two": NewNumber(12)}
 
 	copyOfVariables := variables.Copy()
-	copyOfVariables["two"] = 23
-	copyOfVariables["three"] = 42
+	copyOfVariables["two"] = NewNumber(23)
+	copyOfVariables["three"] = NewNumber(42)
 
-	assert.Equal(test, VariableGroup{"one": 5, "two": 12}, variables)
+	assert.Equal(test, VariableGroup{"one": NewNumber(5), "two": NewNumber(12)}, variables)
 	assert.Equal(
 		test,
-		VariableGroup{"one": 5, "two": 23, "three": 42},
+		VariableGroup{"one": NewNumber(5), "two": NewNumber(23), "three": NewNumber(42)},
 		copyOfVariables,
 	)
 }
+
+func TestVariableGroup_Merge(test *testing.T) {
+	variables := VariableGroup{"one": NewNumber(5), "two": NewNumber(12)}
+
+	got := variables.Merge(VariableGroup{
+		"two":   NewNumber(23),
+		"three": NewNumber(42),
+	})
+
+	assert.Equal(test, VariableGroup{
+		"one":   NewNumber(5),
+		"two":   NewNumber(23),
+		"three": NewNumber(42),
+	}, got)
+	assert.Equal(test, VariableGroup{
+		"one": NewNumber(5),
+		"two": NewNumber(12),
+	}, variables)
+}
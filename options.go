@@ -0,0 +1,187 @@
+package calculator
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// Option restricts code of the interpreter, the calculator or the program,
+// e.g. for formulas of customers.
+type Option func(options *options)
+
+type options struct {
+	// nil means that all the functions are allowed
+	allowedFunctions  map[string]struct{}
+	readOnlyVariables map[string]struct{}
+	forbidAssignment  bool
+	constants         models.ConstantGroup
+}
+
+func newOptions(optionList []Option) options {
+	var options options
+	for _, option := range optionList {
+		option(&options)
+	}
+
+	return options
+}
+
+// AllowFunctions allows calls of the specified functions only;
+// operators are always allowed. Several options join their lists.
+func AllowFunctions(names ...string) Option {
+	return func(options *options) {
+		if options.allowedFunctions == nil {
+			options.allowedFunctions = map[string]struct{}{}
+		}
+		for _, name := range names {
+			options.allowedFunctions[name] = struct{}{}
+		}
+	}
+}
+
+// ReadOnlyVariables forbids assigning the specified variables
+// and their elements, e.g. ones provided by the host; see also Constants.
+func ReadOnlyVariables(names ...string) Option {
+	return func(options *options) {
+		if options.readOnlyVariables == nil {
+			options.readOnlyVariables = map[string]struct{}{}
+		}
+		for _, name := range names {
+			options.readOnlyVariables[name] = struct{}{}
+		}
+	}
+}
+
+// ForbidAssignment forbids the statements of the interpreter that change
+// its state: the assignment and the mode statement, because copies
+// of the interpreter share the angle mode; the calculator and programs
+// have no statements, so they ignore it.
+func ForbidAssignment() Option {
+	return func(options *options) {
+		options.forbidAssignment = true
+	}
+}
+
+// Constants adds the constants to the variables, so the interpreter
+// forbids reassigning them, e.g. Constants(BuiltInConstants). The constants
+// shadow variables with the same names. Several options join their groups.
+func Constants(constants models.ConstantGroup) Option {
+	return func(options *options) {
+		if options.constants == nil {
+			options.constants = models.ConstantGroup{}
+		}
+		for name, value := range constants {
+			options.constants[name] = value
+		}
+	}
+}
+
+// ViolationKind ...
+type ViolationKind int
+
+// ...
+const (
+	ForbiddenFunctionViolation ViolationKind = iota
+	ReadOnlyVariableViolation
+	ForbiddenAssignmentViolation
+	ConstantAssignmentViolation
+	ForbiddenModeViolation
+)
+
+// Violation is usage of a function, a variable or a statement forbidden
+// by the options; the name of the mode statement violation is the mode.
+// Validate methods return all the violations of code, and calculating
+// returns the first one as an error.
+type Violation struct {
+	Kind ViolationKind
+	Name string
+}
+
+// Error ...
+func (violation Violation) Error() string {
+	switch violation.Kind {
+	case ForbiddenFunctionViolation:
+		return fmt.Sprintf("function %s isn't allowed", violation.Name)
+	case ReadOnlyVariableViolation:
+		return fmt.Sprintf("variable %s is read-only", violation.Name)
+	case ForbiddenAssignmentViolation:
+		return fmt.Sprintf("assignment of variable %s is forbidden", violation.Name)
+	case ForbiddenModeViolation:
+		return fmt.Sprintf("change of the mode to %s is forbidden", violation.Name)
+	default:
+		return fmt.Sprintf("constant %s can't be reassigned", violation.Name)
+	}
+}
+
+func (options options) findAssignmentViolations(name string) []Violation {
+	if options.forbidAssignment {
+		return []Violation{{Kind: ForbiddenAssignmentViolation, Name: name}}
+	}
+	if _, ok := options.readOnlyVariables[name]; ok {
+		return []Violation{{Kind: ReadOnlyVariableViolation, Name: name}}
+	}
+
+	return nil
+}
+
+func (options options) findModeViolations(modeName string) []Violation {
+	if options.forbidAssignment {
+		return []Violation{{Kind: ForbiddenModeViolation, Name: modeName}}
+	}
+
+	return nil
+}
+
+// it returns a violation per function, even if it's called several times
+func (options options) findCommandViolations(commands []models.Command) []Violation {
+	if options.allowedFunctions == nil {
+		return nil
+	}
+
+	var violations []Violation
+	foundFunctions := map[string]struct{}{}
+	for _, command := range commands {
+		if command.Kind != models.CallFunctionCommand ||
+			isOperator(command.Operand) {
+			continue
+		}
+		if _, ok := options.allowedFunctions[command.Operand]; ok {
+			continue
+		}
+		if _, ok := foundFunctions[command.Operand]; ok {
+			continue
+		}
+
+		foundFunctions[command.Operand] = struct{}{}
+		violations = append(violations, Violation{
+			Kind: ForbiddenFunctionViolation,
+			Name: command.Operand,
+		})
+	}
+
+	return violations
+}
+
+func (options options) checkCommands(commands []models.Command) error {
+	if violations := options.findCommandViolations(commands); len(violations) != 0 {
+		return &Error{
+			Stage:   TranslationStage,
+			Message: "unable to validate the commands",
+			Err:     violations[0],
+		}
+	}
+
+	return nil
+}
+
+func isOperator(name string) bool {
+	symbol, size := utf8.DecodeRuneInString(name)
+	if size != len(name) {
+		return false
+	}
+
+	kind, err := models.ParseTokenKind(symbol)
+	return err == nil && kind.IsOperator()
+}
@@ -0,0 +1,228 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpreter_withOptions(test *testing.T) {
+	type args struct {
+		options []Option
+		input   string
+	}
+
+	testsCases := []struct {
+		name          string
+		args          args
+		wantVariables models.VariableGroup
+		wantValue     models.Value
+		wantErr       string
+	}{
+		{
+			name: "success with the allowed functions",
+			args: args{
+				options: []Option{AllowFunctions("sqrt"), AllowFunctions("max")},
+				input:   "y = max(sqrt(x) * 2, 1)",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+				"y":  models.NewNumber(4),
+			},
+			wantValue: models.NewNumber(4),
+			wantErr:   "",
+		},
+		{
+			name: "success with the read-only variables",
+			args: args{
+				options: []Option{ReadOnlyVariables("pi")},
+				input:   "x = pi",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(3.14),
+			},
+			wantValue: models.NewNumber(3.14),
+			wantErr:   "",
+		},
+		{
+			name: "success with the forbidden assignment",
+			args: args{
+				options: []Option{ForbidAssignment()},
+				input:   "x + 1",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+			},
+			wantValue: models.NewNumber(5),
+			wantErr:   "",
+		},
+		{
+			name: "error with the function",
+			args: args{
+				options: []Option{AllowFunctions("sqrt")},
+				input:   "y = max(sqrt(x), 1)",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to calculate the code: " +
+				"unable to validate the commands: " +
+				"function max isn't allowed",
+		},
+		{
+			name: "error with the function in the print statement",
+			args: args{
+				options: []Option{AllowFunctions()},
+				input:   "print x, sqrt(x)",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to print: " +
+				"unable to validate the commands: " +
+				"function sqrt isn't allowed",
+		},
+		{
+			name: "error with the read-only variable",
+			args: args{
+				options: []Option{ReadOnlyVariables("pi")},
+				input:   "pi[0] = 3",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"variable pi is read-only",
+		},
+		{
+			name: "error with the forbidden assignment",
+			args: args{
+				options: []Option{ForbidAssignment()},
+				input:   "x = x + 1",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to assign the variable: " +
+				"unable to validate the assignment: " +
+				"assignment of variable x is forbidden",
+		},
+		{
+			name: "error with the forbidden mode statement",
+			args: args{
+				options: []Option{ForbidAssignment()},
+				input:   "mode deg",
+			},
+			wantVariables: models.VariableGroup{
+				"pi": models.NewNumber(3.14),
+				"x":  models.NewNumber(4),
+			},
+			wantValue: models.Value{},
+			wantErr: "unable to set the mode: " +
+				"unable to validate the mode statement: " +
+				"change of the mode to deg is forbidden",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			interpreter := NewInterpreter(
+				models.VariableGroup{
+					"pi": models.NewNumber(3.14),
+					"x":  models.NewNumber(4),
+				},
+				BuiltInFunctions,
+				testCase.args.options...,
+			)
+			gotValue, gotErr := interpreter.Interpret(testCase.args.input)
+
+			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
+			assert.Equal(test, RadianMode, interpreter.AngleMode())
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+
+				var violation Violation
+				assert.True(test, errors.As(gotErr, &violation))
+			}
+		})
+	}
+}
+
+func TestCalculator_withOptions(test *testing.T) {
+	calculator := NewCalculator(
+		models.VariableGroup{"x": models.NewNumber(4)},
+		BuiltInFunctions,
+		AllowFunctions("sqrt"),
+	)
+	err := calculator.Calculate("sqrt(x) + ")
+	require.NoError(test, err)
+
+	err = calculator.Calculate("abs(x)")
+	assert.EqualError(
+		test,
+		err,
+		"unable to validate the commands: function abs isn't allowed",
+	)
+}
+
+func TestCalculator_withConstants(test *testing.T) {
+	calculator := NewCalculator(
+		models.VariableGroup{"x": models.NewNumber(2), "pi": models.NewNumber(4)},
+		BuiltInFunctions,
+		Constants(models.ConstantGroup{"pi": models.NewNumber(3)}),
+	)
+	err := calculator.Calculate("x * pi")
+	require.NoError(test, err)
+
+	value, err := calculator.Finalize()
+	require.NoError(test, err)
+
+	assert.Equal(test, models.NewNumber(6), value)
+}
+
+func TestCompile_withConstants(test *testing.T) {
+	program, err := Compile(
+		"x * pi",
+		BuiltInFunctions,
+		Limits{},
+		Constants(models.ConstantGroup{"pi": models.NewNumber(3)}),
+	)
+	require.NoError(test, err)
+
+	value, err := program.Eval(models.VariableGroup{"x": models.NewNumber(2)})
+	require.NoError(test, err)
+
+	assert.Equal(test, models.NewNumber(6), value)
+}
+
+func TestCompile_withOptions(test *testing.T) {
+	_, err := Compile(
+		"sqrt(x) + abs(x)",
+		BuiltInFunctions,
+		Limits{},
+		AllowFunctions("sqrt"),
+	)
+
+	assert.EqualError(
+		test,
+		err,
+		"unable to validate the commands: function abs isn't allowed",
+	)
+}
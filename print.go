@@ -0,0 +1,78 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+func (interpreter Interpreter) print(ctx context.Context, code string) error {
+	tokens, err := tokenizeCode(code)
+	if err != nil {
+		return err
+	}
+
+	text := ""
+	functions := interpreter.wrapFunctions()
+	arguments, err := splitArguments(tokens)
+	if err != nil {
+		return err
+	}
+
+	for _, argument := range arguments {
+		calculator := interpreter.newCalculator(ctx, functions)
+		value, err := calculator.finalizeTokens(argument)
+		if err != nil {
+			return err
+		}
+
+		text += value.String()
+	}
+
+	interpreter.outputLock.Lock()
+	defer interpreter.outputLock.Unlock()
+
+	_, err = io.WriteString(interpreter.output, text)
+	return err
+}
+
+// it returns an error for an empty argument, e.g. in "print 1,,2",
+// because the translator can't distinguish it from the absent code
+func splitArguments(tokens []models.Token) ([][]models.Token, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	arguments := [][]models.Token{nil}
+	depth := 0
+	for _, token := range tokens {
+		switch token.Kind {
+		case models.LeftParenthesisToken, models.LeftBracketToken:
+			depth++
+		case models.RightParenthesisToken, models.RightBracketToken:
+			depth--
+		case models.CommaToken:
+			if depth == 0 {
+				arguments = append(arguments, nil)
+				continue
+			}
+		}
+
+		lastArgument := &arguments[len(arguments)-1]
+		*lastArgument = append(*lastArgument, token)
+	}
+
+	for argumentIndex, argument := range arguments {
+		if len(argument) == 0 {
+			return nil, &Error{
+				Stage:   TranslationStage,
+				Message: "unable to split the arguments",
+				Err:     fmt.Errorf("argument #%d is empty", argumentIndex),
+			}
+		}
+	}
+
+	return arguments, nil
+}
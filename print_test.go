@@ -0,0 +1,111 @@
+package calculator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpreter_withPrint(test *testing.T) {
+	type args struct {
+		input string
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:       "success with strings",
+			args:       args{input: `print "one", "two\n"`},
+			wantOutput: "onetwo\n",
+			wantErr:    ErrNoValue.Error(),
+		},
+		{
+			name: "success with expressions",
+			args: args{
+				input: `print "x = ", x, ", y = ", 2 * atan2(x, 2), "\n"`,
+			},
+			wantOutput: "x = 2, y = 1.5707963267948966\n",
+			wantErr:    ErrNoValue.Error(),
+		},
+		{
+			name:       "success with arrays",
+			args:       args{input: `print [1, 2], [x, [3, 4]][1], "\n"`},
+			wantOutput: "[1, 2][3, 4]\n",
+			wantErr:    ErrNoValue.Error(),
+		},
+		{
+			name:       "success with the comment",
+			args:       args{input: `print "// test" // test`},
+			wantOutput: "// test",
+			wantErr:    ErrNoValue.Error(),
+		},
+		{
+			name:       "success without arguments",
+			args:       args{input: "print"},
+			wantOutput: "",
+			wantErr:    ErrNoValue.Error(),
+		},
+
+		// errors
+		{
+			name:       "error with tokenization",
+			args:       args{input: `print "test`},
+			wantOutput: "",
+			wantErr: "unable to print: " +
+				"unable to finalize the tokenizer: " +
+				"unterminated string literal at EOI",
+		},
+		{
+			name:       "error with an empty argument",
+			args:       args{input: `print "test", , x`},
+			wantOutput: "",
+			wantErr: "unable to print: " +
+				"unable to split the arguments: " +
+				"argument #1 is empty",
+		},
+		{
+			name:       "error with an empty first argument",
+			args:       args{input: `print , x`},
+			wantOutput: "",
+			wantErr: "unable to print: " +
+				"unable to split the arguments: " +
+				"argument #0 is empty",
+		},
+		{
+			name:       "error with an empty last argument",
+			args:       args{input: `print x,`},
+			wantOutput: "",
+			wantErr: "unable to print: " +
+				"unable to split the arguments: " +
+				"argument #1 is empty",
+		},
+		{
+			name:       "error with a string in an expression",
+			args:       args{input: `print x + "test"`},
+			wantOutput: "",
+			wantErr: "unable to print: " +
+				"unable to evaluate the commands: " +
+				"unable to call the function from command {Kind:2 Operand:+ ArgumentCount:0} " +
+				"with number #2: argument #1 has type string, but number is expected",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			var output bytes.Buffer
+			interpreter := NewInterpreter(
+				models.VariableGroup{"x": models.NewNumber(2)},
+				BuiltInFunctions,
+			).WithOutput(&output)
+			_, gotErr := interpreter.Interpret(testCase.args.input)
+
+			assert.Equal(test, testCase.wantOutput, output.String())
+			assert.EqualError(test, gotErr, testCase.wantErr)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package calculator
+
+import (
+	"context"
+
+	"github.com/irenicaa/go-calculator/v2/evaluator"
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+	"github.com/irenicaa/go-calculator/v2/translator"
+)
+
+// Program is code translated to commands once, so it may be evaluated
+// many times with different variables.
+//
+// It's safe for concurrent use, because each evaluation uses
+// a fresh evaluator, if the variables aren't changed during evaluations.
+type Program struct {
+	commands  []models.Command
+	functions models.FunctionProvider
+	constants models.ConstantGroup
+	limits    Limits
+	resolver  evaluator.VariableResolver
+}
+
+// Compile translates the code with the specified functions;
+// the limits are checked here for the input length and on evaluations
+// for the rest, and the options are checked here only.
+func Compile(
+	code string,
+	functions models.FunctionProvider,
+	limits Limits,
+	options ...Option,
+) (Program, error) {
+	if err := limits.checkInputLength(code); err != nil {
+		return Program{}, err
+	}
+
+	tokens, err := tokenizeCode(code)
+	if err != nil {
+		return Program{}, err
+	}
+
+	commands, err := translateTokens(tokens, functions)
+	if err != nil {
+		return Program{}, err
+	}
+	programOptions := newOptions(options)
+	if err := programOptions.checkCommands(commands); err != nil {
+		return Program{}, err
+	}
+
+	return Program{
+		commands:  commands,
+		functions: functions,
+		constants: programOptions.constants,
+		limits:    limits,
+	}, nil
+}
+
+// WithResolver returns a copy of the program that consults the resolver
+// for variables missed in the variables of an evaluation; results
+// of the resolver are cached for each evaluation.
+func (program Program) WithResolver(resolver evaluator.VariableResolver) Program {
+	program.resolver = resolver
+	return program
+}
+
+// Eval ...
+func (program Program) Eval(variables models.VariableGroup) (models.Value, error) {
+	return program.EvalContext(context.Background(), variables)
+}
+
+// EvalContext stops the evaluation with an error of the context
+// when the context is done.
+func (program Program) EvalContext(
+	ctx context.Context,
+	variables models.VariableGroup,
+) (models.Value, error) {
+	maxVariableCount := program.limits.MaxVariableCount
+	if maxVariableCount != 0 && len(variables) > maxVariableCount {
+		return models.Value{}, VariableCountError{MaxVariableCount: maxVariableCount}
+	}
+
+	if len(program.constants) != 0 {
+		variables = variables.Merge(program.constants.Variables())
+	}
+
+	programEvaluator := evaluator.NewEvaluator(program.limits.evaluatorLimits())
+	if program.resolver != nil {
+		programEvaluator = programEvaluator.WithResolver(program.resolver)
+	}
+	err := programEvaluator.EvaluateContext(
+		ctx,
+		program.commands,
+		variables,
+		program.functions,
+	)
+	if err != nil {
+		return models.Value{}, &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to evaluate the commands",
+			Err:     err,
+		}
+	}
+
+	value, err := programEvaluator.Finalize()
+	if err != nil {
+		return models.Value{}, &Error{
+			Stage:   EvaluationStage,
+			Message: "unable to finalize the evaluator",
+			Err:     err,
+		}
+	}
+
+	return value, nil
+}
+
+func tokenizeCode(code string) ([]models.Token, error) {
+	var codeTokenizer tokenizer.Tokenizer
+	tokens, err := codeTokenizer.Tokenize(code)
+	if err != nil {
+		return nil, &Error{
+			Stage:   TokenizationStage,
+			Message: "unable to tokenize the code",
+			Err:     err,
+		}
+	}
+
+	additionalTokens, err := codeTokenizer.Finalize()
+	if err != nil {
+		return nil, &Error{
+			Stage:   TokenizationStage,
+			Message: "unable to finalize the tokenizer",
+			Err:     err,
+		}
+	}
+
+	return append(tokens, additionalTokens...), nil
+}
+
+func translateTokens(
+	tokens []models.Token,
+	functions models.FunctionProvider,
+) ([]models.Command, error) {
+	var tokensTranslator translator.Translator
+	commands, err := tokensTranslator.Translate(tokens, functions)
+	if err != nil {
+		return nil, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to translate the tokens",
+			Err:     err,
+		}
+	}
+
+	additionalCommands, err := tokensTranslator.Finalize()
+	if err != nil {
+		return nil, &Error{
+			Stage:   TranslationStage,
+			Message: "unable to finalize the translator",
+			Err:     err,
+		}
+	}
+
+	return append(commands, additionalCommands...), nil
+}
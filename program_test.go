@@ -0,0 +1,190 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/evaluator"
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgram(test *testing.T) {
+	type args struct {
+		code      string
+		limits    Limits
+		variables models.VariableGroup
+	}
+
+	testsCases := []struct {
+		name        string
+		args        args
+		wantValue   models.Value
+		wantErr     string
+		wantEvalErr string
+	}{
+		{
+			name: "success",
+			args: args{
+				code:      "x^2 + sum(k, k, 1, x)",
+				limits:    Limits{},
+				variables: models.VariableGroup{"x": models.NewNumber(3)},
+			},
+			wantValue: models.NewNumber(15),
+		},
+		{
+			name: "success with limits",
+			args: args{
+				code: "x^2 + sum(k, k, 1, x)",
+				limits: Limits{
+					MaxInputLength:   21,
+					MaxVariableCount: 1,
+					MaxCommandCount:  16,
+					MaxStackDepth:    5,
+				},
+				variables: models.VariableGroup{"x": models.NewNumber(3)},
+			},
+			wantValue: models.NewNumber(15),
+		},
+		{
+			name: "error with the tokenization",
+			args: args{
+				code:      "2 @ 3",
+				limits:    Limits{},
+				variables: nil,
+			},
+			wantErr: "unable to tokenize the code: unknown symbol '@' at position 2",
+		},
+		{
+			name: "error with the translation",
+			args: args{
+				code:      "(2 + 3",
+				limits:    Limits{},
+				variables: nil,
+			},
+			wantErr: "unable to finalize the translator: missed pair for token " +
+				"{Kind:8 Value:(}",
+		},
+		{
+			name: "error with the input length",
+			args: args{
+				code:      "x^2 + 1",
+				limits:    Limits{MaxInputLength: 6},
+				variables: nil,
+			},
+			wantErr: "input length exceeds the limit of 6",
+		},
+		{
+			name: "error with the variable count",
+			args: args{
+				code:   "x^2 + 1",
+				limits: Limits{MaxVariableCount: 1},
+				variables: models.VariableGroup{
+					"x": models.NewNumber(3),
+					"y": models.NewNumber(4),
+				},
+			},
+			wantEvalErr: "variable count exceeds the limit of 1",
+		},
+		{
+			name: "error with the command count",
+			args: args{
+				code:      "sum(k, k, 1, 10)",
+				limits:    Limits{MaxCommandCount: 12},
+				variables: nil,
+			},
+			wantEvalErr: "unable to evaluate the commands: " +
+				"unable to call the function from command " +
+				"{Kind:2 Operand:sum ArgumentCount:4} with number #8: " +
+				"unable to evaluate the expression for k = 4: " +
+				"unable to execute command " +
+				"{Kind:1 Operand:k ArgumentCount:0} with number #0: " +
+				"command count exceeds the limit of 12",
+		},
+		{
+			name: "error with the stack depth",
+			args: args{
+				code:      "[1, 2, 3]",
+				limits:    Limits{MaxStackDepth: 2},
+				variables: nil,
+			},
+			wantEvalErr: "unable to evaluate the commands: " +
+				"unable to execute command " +
+				"{Kind:5 Operand:3 ArgumentCount:0} with number #3: " +
+				"stack depth exceeds the limit of 2",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			program, err := Compile(
+				testCase.args.code,
+				BuiltInFunctions,
+				testCase.args.limits,
+			)
+			if testCase.wantErr != "" {
+				assert.EqualError(test, err, testCase.wantErr)
+				return
+			}
+			require.NoError(test, err)
+
+			gotValue, gotErr := program.Eval(testCase.args.variables)
+
+			assert.Equal(test, testCase.wantValue, gotValue)
+			if testCase.wantEvalErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantEvalErr)
+			}
+		})
+	}
+}
+
+func TestProgram_withSequentialCalls(test *testing.T) {
+	program, err := Compile("2 x + 1", BuiltInFunctions, Limits{})
+	require.NoError(test, err)
+
+	for x := 0; x < 3; x++ {
+		value, err := program.Eval(models.VariableGroup{
+			"x": models.NewNumber(float64(x)),
+		})
+		require.NoError(test, err)
+
+		assert.Equal(test, models.NewNumber(float64(2*x+1)), value)
+	}
+}
+
+func TestProgram_WithResolver(test *testing.T) {
+	program, err := Compile("price * count + price", BuiltInFunctions, Limits{})
+	require.NoError(test, err)
+
+	var resolvedNames []string
+	program = program.WithResolver(evaluator.VariableResolverFunc(
+		func(name string) (float64, bool, error) {
+			resolvedNames = append(resolvedNames, name)
+			return 10, name == "price", nil
+		},
+	))
+
+	for count := 1; count < 3; count++ {
+		resolvedNames = nil
+		value, err := program.Eval(models.VariableGroup{
+			"count": models.NewNumber(float64(count)),
+		})
+		require.NoError(test, err)
+
+		assert.Equal(test, models.NewNumber(float64(10*count+10)), value)
+		// the results are cached for each evaluation
+		assert.Equal(test, []string{"price"}, resolvedNames)
+	}
+}
+
+func TestCompile_withFunctionNames(test *testing.T) {
+	// the names suffice for the translation, but not for the evaluation
+	program, err := Compile("rand()", BuiltInFunctions.Names(), Limits{})
+	require.NoError(test, err)
+
+	value, err := program.Eval(models.VariableGroup{})
+	assert.Equal(test, models.Value{}, value)
+	assert.True(test, errors.Is(err, models.ErrNoHandler))
+}
@@ -0,0 +1,129 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// the built-in functions may be used concurrently,
+// so they use a source with a lock
+var defaultRandomFunctions = NewRandomFunctions(
+	newLockedRandom(time.Now().UnixNano()),
+)
+
+// NewRandomFunctions returns the functions that generate random numbers
+// with the specified generator. They're impure, and BuiltInFunctions
+// contains them bound to a default generator; see also Interpreter.WithSeed.
+//
+// The functions are safe for concurrent use if the source of the generator
+// is, e.g. the one of BuiltInFunctions or of Interpreter.
+func NewRandomFunctions(random *rand.Rand) models.FunctionGroup {
+	// the reseeding also resets the state of the generator itself,
+	// not only of its source
+	var seedLock sync.Mutex
+	return models.FunctionGroup{
+		"rand": {
+			Arity:  0,
+			Impure: true,
+			Handler: func(arguments []float64) (float64, error) {
+				return random.Float64(), nil
+			},
+		},
+		"randint": {
+			Arity:  2,
+			Impure: true,
+			Handler: func(arguments []float64) (float64, error) {
+				for argumentIndex := range arguments {
+					if err := checkInteger(arguments, argumentIndex); err != nil {
+						return 0, err
+					}
+				}
+
+				minimum, maximum := arguments[0], arguments[1]
+				if minimum > maximum {
+					return 0, errors.New("argument #0 shouldn't be greater than argument #1")
+				}
+
+				count := maximum - minimum + 1
+				if count >= math.MaxInt64 {
+					return 0, errors.New("range is too large")
+				}
+
+				return minimum + float64(random.Int63n(int64(count))), nil
+			},
+		},
+		"normal": {
+			Arity:  2,
+			Impure: true,
+			Handler: func(arguments []float64) (float64, error) {
+				if err := checkLowerBound(arguments, 1, 0); err != nil {
+					return 0, err
+				}
+
+				return arguments[0] + arguments[1]*random.NormFloat64(), nil
+			},
+		},
+		"seed": {
+			Arity:  1,
+			Impure: true,
+			Handler: func(arguments []float64) (float64, error) {
+				if err := checkInteger(arguments, 0); err != nil {
+					return 0, err
+				}
+
+				seedLock.Lock()
+				defer seedLock.Unlock()
+
+				random.Seed(int64(arguments[0]))
+				return arguments[0], nil
+			},
+		},
+	}
+}
+
+// it replaces the random functions of the group
+// with ones that use the specified generator
+func bindRandomFunctions(
+	functions models.FunctionGroup,
+	random *rand.Rand,
+) models.FunctionGroup {
+	boundFunctions := models.FunctionGroup{}
+	for name, function := range NewRandomFunctions(random) {
+		if existingFunction, ok := functions[name]; ok && existingFunction.Impure {
+			boundFunctions[name] = function
+		}
+	}
+	if len(boundFunctions) == 0 {
+		return functions
+	}
+
+	return functions.Merge(boundFunctions)
+}
+
+func newLockedRandom(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{source: rand.NewSource(seed)})
+}
+
+type lockedSource struct {
+	mutex  sync.Mutex
+	source rand.Source
+}
+
+func (source *lockedSource) Int63() int64 {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	return source.source.Int63()
+}
+
+func (source *lockedSource) Seed(seed int64) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	source.source.Seed(seed)
+}
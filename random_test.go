@@ -0,0 +1,164 @@
+package calculator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRandomFunctions(test *testing.T) {
+	type args struct {
+		name      string
+		arguments []float64
+	}
+
+	testsCases := []struct {
+		name    string
+		args    args
+		wantMin float64
+		wantMax float64
+		wantErr string
+	}{
+		{
+			name: "rand",
+			args: args{
+				name:      "rand",
+				arguments: nil,
+			},
+			wantMin: 0,
+			wantMax: 1,
+			wantErr: "",
+		},
+		{
+			name: "randint/success",
+			args: args{
+				name:      "randint",
+				arguments: []float64{-2, 3},
+			},
+			wantMin: -2,
+			wantMax: 3,
+			wantErr: "",
+		},
+		{
+			name: "randint/success with a single value",
+			args: args{
+				name:      "randint",
+				arguments: []float64{5, 5},
+			},
+			wantMin: 5,
+			wantMax: 5,
+			wantErr: "",
+		},
+		{
+			name: "randint/error with a fraction",
+			args: args{
+				name:      "randint",
+				arguments: []float64{1, 2.5},
+			},
+			wantMin: 0,
+			wantMax: 0,
+			wantErr: "argument #1 isn't an integer",
+		},
+		{
+			name: "randint/error with the range",
+			args: args{
+				name:      "randint",
+				arguments: []float64{6, 1},
+			},
+			wantMin: 0,
+			wantMax: 0,
+			wantErr: "argument #0 shouldn't be greater than argument #1",
+		},
+		{
+			name: "normal/success with a zero deviation",
+			args: args{
+				name:      "normal",
+				arguments: []float64{10, 0},
+			},
+			wantMin: 10,
+			wantMax: 10,
+			wantErr: "",
+		},
+		{
+			name: "normal/error",
+			args: args{
+				name:      "normal",
+				arguments: []float64{10, -1},
+			},
+			wantMin: 0,
+			wantMax: 0,
+			wantErr: "argument #1 should be at least 0",
+		},
+		{
+			name: "seed/success",
+			args: args{
+				name:      "seed",
+				arguments: []float64{42},
+			},
+			wantMin: 42,
+			wantMax: 42,
+			wantErr: "",
+		},
+		{
+			name: "seed/error",
+			args: args{
+				name:      "seed",
+				arguments: []float64{0.5},
+			},
+			wantMin: 0,
+			wantMax: 0,
+			wantErr: "argument #0 isn't an integer",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			functions := NewRandomFunctions(rand.New(rand.NewSource(1)))
+			gotFunction, gotOk := functions[testCase.args.name]
+			require.True(test, gotOk)
+
+			for attempt := 0; attempt < 100; attempt++ {
+				gotResult, gotErr := gotFunction.Handler(testCase.args.arguments)
+
+				assert.True(test, gotFunction.Impure)
+				assert.GreaterOrEqual(test, gotResult, testCase.wantMin)
+				assert.LessOrEqual(test, gotResult, testCase.wantMax)
+				if testCase.wantErr == "" {
+					assert.NoError(test, gotErr)
+				} else {
+					assert.EqualError(test, gotErr, testCase.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestInterpreter_withSeed(test *testing.T) {
+	interpret := func(interpreter Interpreter, inputs []string) []models.Value {
+		values := []models.Value{}
+		for _, input := range inputs {
+			value, err := interpreter.Interpret(input)
+			require.NoError(test, err)
+
+			values = append(values, value)
+		}
+
+		return values
+	}
+
+	inputs := []string{"rand()", "randint(1, 100)", "normal(0, 1)"}
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions)
+	firstValues := interpret(interpreter.WithSeed(42), inputs)
+	secondValues := interpret(interpreter.WithSeed(42), inputs)
+	reseededValues := interpret(
+		interpreter.WithSeed(23),
+		append([]string{"seed(42)"}, inputs...),
+	)
+	otherValues := interpret(interpreter.WithSeed(23), inputs)
+
+	assert.Equal(test, firstValues, secondValues)
+	assert.Equal(test, firstValues, reseededValues[1:])
+	assert.NotEqual(test, firstValues, otherValues)
+}
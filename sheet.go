@@ -0,0 +1,187 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+)
+
+// Sheet keeps formulas of cells like "total = price * count"
+// and recalculates the cells depending on a cell when it's updated,
+// like a spreadsheet. Values of the cells are kept as variables
+// of the interpreter.
+//
+// It's safe for concurrent use, because updates are serialized.
+type Sheet struct {
+	interpreter Interpreter
+	formulas    map[string]string
+	// variables referenced by each formula
+	dependencies map[string][]string
+	lock         sync.Mutex
+}
+
+// NewSheet ...
+func NewSheet(interpreter Interpreter) *Sheet {
+	return &Sheet{
+		interpreter:  interpreter,
+		formulas:     map[string]string{},
+		dependencies: map[string][]string{},
+	}
+}
+
+// Formula returns the assignment statement of the cell.
+func (sheet *Sheet) Formula(name string) (formula string, ok bool) {
+	sheet.lock.Lock()
+	defer sheet.lock.Unlock()
+
+	formula, ok = sheet.formulas[name]
+	return formula, ok
+}
+
+// Value returns the last calculated value of the cell.
+func (sheet *Sheet) Value(name string) (value models.Value, ok bool) {
+	sheet.lock.Lock()
+	defer sheet.lock.Unlock()
+
+	if _, ok := sheet.formulas[name]; !ok {
+		return models.Value{}, false
+	}
+
+	value, ok = sheet.interpreter.Variables()[name]
+	return value, ok
+}
+
+// Set sets the formula of the cell by the assignment statement,
+// e.g. "total = price * count", and recalculates the cell and the cells
+// depending on it in topological order, so each cell is calculated
+// after the cells it references.
+//
+// A formula that makes a cycle of cells is rejected with CycleError.
+// If a recalculation fails, e.g. because a referenced cell isn't set yet,
+// the formula is kept, so it's recalculated when the referenced cells
+// are updated, and the error is returned with the name of the failed cell;
+// the following cells aren't recalculated in this case.
+func (sheet *Sheet) Set(input string) error {
+	sheet.lock.Lock()
+	defer sheet.lock.Unlock()
+
+	dependencies, err := FindDependencies(input, sheet.interpreter.allFunctions())
+	if err != nil {
+		return fmt.Errorf("unable to find the dependencies: %w", err)
+	}
+
+	name := dependencies.AssignedVariable
+	variable, _ := tokenizer.ExtractVariable(tokenizer.RemoveComment(input))
+	if name == "" || variable != name {
+		return errors.New(
+			"formula should be an assignment of a cell without indexes and constants",
+		)
+	}
+
+	visitedCells := map[string]struct{}{}
+	for _, dependency := range dependencies.Variables {
+		if path, ok := sheet.findPath(dependency, name, visitedCells); ok {
+			return CycleError{Cells: append([]string{name}, path...)}
+		}
+	}
+
+	sheet.formulas[name] = input
+	sheet.dependencies[name] = dependencies.Variables
+	for _, cell := range sheet.sortAffectedCells(name) {
+		if _, err := sheet.interpreter.Interpret(sheet.formulas[cell]); err != nil {
+			return fmt.Errorf("unable to recalculate the cell %s: %w", cell, err)
+		}
+	}
+
+	return nil
+}
+
+// it returns a path of cells from the start to the end by the dependencies
+// of their formulas, including both of them; the visited cells have no path
+// to the end, so they're skipped, and each cell is checked once
+func (sheet *Sheet) findPath(
+	start string,
+	end string,
+	visitedCells map[string]struct{},
+) ([]string, bool) {
+	if start == end {
+		return []string{end}, true
+	}
+	if _, ok := visitedCells[start]; ok {
+		return nil, false
+	}
+	visitedCells[start] = struct{}{}
+
+	for _, dependency := range sheet.dependencies[start] {
+		if path, ok := sheet.findPath(dependency, end, visitedCells); ok {
+			return append([]string{start}, path...), true
+		}
+	}
+
+	return nil, false
+}
+
+// it returns the cell and the cells depending on it, directly or not,
+// so that each cell follows the cells it references
+func (sheet *Sheet) sortAffectedCells(name string) []string {
+	dependents := map[string][]string{}
+	for cell, dependencies := range sheet.dependencies {
+		for _, dependency := range dependencies {
+			dependents[dependency] = append(dependents[dependency], cell)
+		}
+	}
+
+	affectedCells := map[string]struct{}{name: {}}
+	queue := []string{name}
+	for len(queue) != 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		for _, dependent := range dependents[cell] {
+			if _, ok := affectedCells[dependent]; !ok {
+				affectedCells[dependent] = struct{}{}
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	var sortedCells []string
+	visitedCells := map[string]struct{}{}
+	var visit func(cell string)
+	visit = func(cell string) {
+		visitedCells[cell] = struct{}{}
+		for _, dependency := range sheet.dependencies[cell] {
+			_, isAffected := affectedCells[dependency]
+			_, isVisited := visitedCells[dependency]
+			if isAffected && !isVisited {
+				visit(dependency)
+			}
+		}
+
+		sortedCells = append(sortedCells, cell)
+	}
+	// the names are sorted for a stable order of independent cells
+	for _, cell := range sortNames(affectedCells) {
+		if _, ok := visitedCells[cell]; !ok {
+			visit(cell)
+		}
+	}
+
+	return sortedCells
+}
+
+// CycleError is returned when a formula references its own cell,
+// directly or via other cells.
+type CycleError struct {
+	// the path of the cycle; it starts and ends with the same cell
+	Cells []string
+}
+
+// Error ...
+func (err CycleError) Error() string {
+	return fmt.Sprintf("cycle of the cells %s", strings.Join(err.Cells, " -> "))
+}
@@ -0,0 +1,203 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheet_Set(test *testing.T) {
+	testsCases := []struct {
+		name          string
+		inputs        []string
+		wantVariables models.VariableGroup
+		wantErr       string
+	}{
+		{
+			name: "success with the dependent cells",
+			inputs: []string{
+				"price = 10",
+				"count = 3",
+				"rate = 0.5",
+				"tax = price * count * rate",
+				"total = price * count + tax",
+				"price = 20",
+			},
+			wantVariables: models.VariableGroup{
+				"total": models.NewNumber(90),
+				"tax":   models.NewNumber(30),
+				"price": models.NewNumber(20),
+				"count": models.NewNumber(3),
+				"rate":  models.NewNumber(0.5),
+			},
+			wantErr: "",
+		},
+		{
+			name: "success with the changed dependencies",
+			inputs: []string{
+				"x = 2",
+				"y = 3",
+				"z = x * 10",
+				"z = y * 10",
+				"x = 5",
+				"y = 4",
+			},
+			wantVariables: models.VariableGroup{
+				"x": models.NewNumber(5),
+				"y": models.NewNumber(4),
+				"z": models.NewNumber(40),
+			},
+			wantErr: "",
+		},
+		{
+			name:          "error with the self-reference",
+			inputs:        []string{"x = x + 1"},
+			wantVariables: models.VariableGroup{},
+			wantErr:       "cycle of the cells x -> x",
+		},
+		{
+			name:   "error with the cycle",
+			inputs: []string{"x = 1", "y = x + 1", "z = y + 1", "x = z + 1"},
+			wantVariables: models.VariableGroup{
+				"x": models.NewNumber(1),
+				"y": models.NewNumber(2),
+				"z": models.NewNumber(3),
+			},
+			wantErr: "cycle of the cells x -> z -> y -> x",
+		},
+		{
+			name:          "error with the unknown cell",
+			inputs:        []string{"y = x + 1", "x = 2"},
+			wantVariables: models.VariableGroup{},
+			wantErr: "unable to recalculate the cell y: " +
+				"unable to calculate the code: " +
+				"unable to evaluate the commands: " +
+				"unknown variable in command " +
+				"{Kind:1 Operand:x ArgumentCount:0} with number #0",
+		},
+		{
+			name:          "error with the expression",
+			inputs:        []string{"2 + 3"},
+			wantVariables: models.VariableGroup{},
+			wantErr: "formula should be an assignment " +
+				"of a cell without indexes and constants",
+		},
+		{
+			name:          "error with the indexes",
+			inputs:        []string{"x[0] = 2"},
+			wantVariables: models.VariableGroup{},
+			wantErr: "formula should be an assignment " +
+				"of a cell without indexes and constants",
+		},
+		{
+			name:   "error with the recalculation",
+			inputs: []string{"x = 2", "y = sqrt(x)", "x = \"two\""},
+			wantVariables: models.VariableGroup{
+				"x": models.NewString("two"),
+				"y": models.NewNumber(1.4142135623730951),
+			},
+			wantErr: "unable to recalculate the cell y: " +
+				"unable to calculate the code: " +
+				"unable to evaluate the commands: " +
+				"unable to call the function from command " +
+				"{Kind:2 Operand:sqrt ArgumentCount:1} with number #1: " +
+				"argument #0 has type string, but number is expected",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions)
+			sheet := NewSheet(interpreter)
+
+			var gotErr error
+			for _, input := range testCase.inputs {
+				if gotErr = sheet.Set(input); gotErr != nil {
+					break
+				}
+			}
+
+			assert.Equal(test, testCase.wantVariables, interpreter.Variables())
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestSheet_withAffectedCells(test *testing.T) {
+	var calculatedCells []string
+	functions := BuiltInFunctions.Merge(models.FunctionGroup{
+		"mark": {
+			Arity: 2,
+			ValueHandler: func(arguments []models.Value) (models.Value, error) {
+				calculatedCells = append(calculatedCells, arguments[0].Text)
+				return arguments[1], nil
+			},
+		},
+	})
+
+	interpreter := NewInterpreter(models.VariableGroup{}, functions)
+	sheet := NewSheet(interpreter)
+	for _, input := range []string{
+		"a = mark(\"a\", 1)",
+		"b = mark(\"b\", 2)",
+		"c = mark(\"c\", a + 1)",
+		"d = mark(\"d\", c + b)",
+		"e = mark(\"e\", c * 2)",
+	} {
+		err := sheet.Set(input)
+		assert.NoError(test, err)
+	}
+
+	calculatedCells = nil
+	err := sheet.Set("a = mark(\"a\", 10)")
+
+	assert.NoError(test, err)
+	// b isn't affected, and c is calculated before d and e
+	assert.Equal(test, []string{"a", "c", "d", "e"}, calculatedCells)
+
+	gotValue, gotOk := sheet.Value("d")
+	assert.Equal(test, models.NewNumber(13), gotValue)
+	assert.True(test, gotOk)
+
+	gotFormula, gotOk := sheet.Formula("a")
+	assert.Equal(test, "a = mark(\"a\", 10)", gotFormula)
+	assert.True(test, gotOk)
+}
+
+func TestSheet_withDiamonds(test *testing.T) {
+	interpreter := NewInterpreter(models.VariableGroup{}, BuiltInFunctions)
+	sheet := NewSheet(interpreter)
+	err := sheet.Set("c0 = 1")
+	assert.NoError(test, err)
+
+	const diamondCount = 50
+	for index := 1; index <= diamondCount; index++ {
+		for _, input := range []string{
+			fmt.Sprintf("a%d = c%d", index, index-1),
+			fmt.Sprintf("b%d = c%d", index, index-1),
+			fmt.Sprintf("c%d = a%d + b%d", index, index, index),
+		} {
+			err := sheet.Set(input)
+			assert.NoError(test, err)
+		}
+	}
+
+	// each cell is checked once, so the search of the cycle doesn't check
+	// all the 2^50 paths through the diamonds
+	err = sheet.Set(fmt.Sprintf("z = c%d", diamondCount))
+	assert.NoError(test, err)
+
+	err = sheet.Set(fmt.Sprintf("c0 = c%d", diamondCount))
+	var cycleErr CycleError
+	if assert.True(test, errors.As(err, &cycleErr)) {
+		assert.Len(test, cycleErr.Cells, 2*diamondCount+2)
+		assert.Equal(test, "c0", cycleErr.Cells[0])
+		assert.Equal(test, "c0", cycleErr.Cells[len(cycleErr.Cells)-1])
+	}
+}
@@ -0,0 +1,301 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// parameters of iterative solvers; the tolerance and the step
+// of the numerical derivative are relative to the root for large roots
+// and absolute for small ones
+const (
+	maxSolverIterations = 100
+	solverTolerance     = 1e-10
+	derivativeStep      = 1e-6
+	// the bracketing methods shrink the range on each iteration,
+	// so they need more iterations, but they always converge
+	maxBracketingIterations = 200
+)
+
+// it's a function of a single number defined by an expression
+type numericExpression struct {
+	expression models.Expression
+	variable   string
+}
+
+// it parses the first two arguments, the expression and its variable,
+// and checks that the rest of the arguments are numbers
+func parseNumericExpression(
+	arguments []models.Value,
+) (numericExpression, error) {
+	for argumentIndex, argument := range arguments {
+		wantedKind := models.NumberValue
+		if argumentIndex < 2 {
+			wantedKind = models.ExpressionValue
+		}
+		if argument.Kind != wantedKind {
+			return numericExpression{}, models.TypeError{
+				ArgumentIndex: argumentIndex,
+				Kind:          argument.Kind,
+				WantedKind:    wantedKind,
+			}
+		}
+	}
+
+	variable, ok := arguments[1].Expression.VariableName()
+	if !ok {
+		return numericExpression{}, errors.New("argument #1 should be a variable name")
+	}
+
+	return numericExpression{
+		expression: *arguments[0].Expression,
+		variable:   variable,
+	}, nil
+}
+
+func (function numericExpression) evaluate(x float64) (float64, error) {
+	value, err := function.expression.Evaluate(models.VariableGroup{
+		function.variable: models.NewNumber(x),
+	})
+	if err != nil {
+		return 0, fmt.Errorf(
+			"unable to evaluate the expression for %s = %g: %w",
+			function.variable,
+			x,
+			err,
+		)
+	}
+	if value.Kind != models.NumberValue {
+		return 0, fmt.Errorf(
+			"expression has type %s for %s = %g, but number is expected",
+			value.Kind,
+			function.variable,
+			x,
+		)
+	}
+
+	return value.Number, nil
+}
+
+// solve(A, b) solves the linear system,
+// and solve(expression, variable, guess) finds a root of the expression
+func solveEquation(arguments []models.Value) (models.Value, error) {
+	if len(arguments) == 2 {
+		evaluatedArguments := make([]models.Value, 0, len(arguments))
+		for argumentIndex, argument := range arguments {
+			evaluatedArgument, err := argument.Evaluate()
+			if err != nil {
+				return models.Value{}, fmt.Errorf(
+					"unable to evaluate argument #%d: %w",
+					argumentIndex,
+					err,
+				)
+			}
+
+			evaluatedArguments = append(evaluatedArguments, evaluatedArgument)
+		}
+
+		return solve(evaluatedArguments)
+	}
+
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	root, err := solveNewton(function.evaluate, arguments[2].Number)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(root), nil
+}
+
+// bisect(expression, variable, lower, upper)
+func bisect(arguments []models.Value) (models.Value, error) {
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	root, err := solveBisection(
+		function.evaluate,
+		arguments[2].Number,
+		arguments[3].Number,
+	)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(root), nil
+}
+
+// minimize(expression, variable, lower, upper)
+func minimize(arguments []models.Value) (models.Value, error) {
+	function, err := parseNumericExpression(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	x, err := minimizeGoldenSection(
+		function.evaluate,
+		arguments[2].Number,
+		arguments[3].Number,
+	)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(x), nil
+}
+
+// it finds a root of the function by the Newton's method
+// with the numerical derivative
+func solveNewton(
+	function func(x float64) (float64, error),
+	guess float64,
+) (float64, error) {
+	x := guess
+	for iteration := 0; iteration < maxSolverIterations; iteration++ {
+		value, err := function(x)
+		if err != nil {
+			return 0, err
+		}
+
+		step := derivativeStep * math.Max(1, math.Abs(x))
+		nextValue, err := function(x + step)
+		if err != nil {
+			return 0, err
+		}
+
+		previousValue, err := function(x - step)
+		if err != nil {
+			return 0, err
+		}
+
+		derivative := (nextValue - previousValue) / (2 * step)
+		if derivative == 0 || math.IsNaN(derivative) || math.IsInf(derivative, 0) {
+			break
+		}
+
+		nextX := x - value/derivative
+		if math.IsNaN(nextX) || math.IsInf(nextX, 0) {
+			break
+		}
+		if math.Abs(nextX-x) <= solverTolerance*math.Max(1, math.Abs(nextX)) {
+			return nextX, nil
+		}
+
+		x = nextX
+	}
+
+	return 0, fmt.Errorf("solver didn't converge from the guess %g", guess)
+}
+
+// it finds a root of the function by the bisection method,
+// so the function should have different signs at the bounds
+func solveBisection(
+	function func(x float64) (float64, error),
+	lower float64,
+	upper float64,
+) (float64, error) {
+	if lower > upper {
+		return 0, errors.New("argument #2 shouldn't be greater than argument #3")
+	}
+
+	lowerValue, err := function(lower)
+	if err != nil {
+		return 0, err
+	}
+	if lowerValue == 0 {
+		return lower, nil
+	}
+
+	upperValue, err := function(upper)
+	if err != nil {
+		return 0, err
+	}
+	if upperValue == 0 {
+		return upper, nil
+	}
+
+	if !(lowerValue*upperValue < 0) {
+		return 0, errors.New("expression should have different signs at the bounds")
+	}
+
+	for iteration := 0; iteration < maxBracketingIterations; iteration++ {
+		middle := lower + (upper-lower)/2
+		if upper-lower <= solverTolerance*math.Max(1, math.Abs(middle)) {
+			break
+		}
+
+		middleValue, err := function(middle)
+		if err != nil {
+			return 0, err
+		}
+		if middleValue == 0 {
+			return middle, nil
+		}
+
+		if (middleValue < 0) == (lowerValue < 0) {
+			lower, lowerValue = middle, middleValue
+		} else {
+			upper = middle
+		}
+	}
+
+	return lower + (upper-lower)/2, nil
+}
+
+// it finds a point of a local minimum of the function
+// by the golden-section search; if the function is unimodal in the range,
+// the minimum is global
+func minimizeGoldenSection(
+	function func(x float64) (float64, error),
+	lower float64,
+	upper float64,
+) (float64, error) {
+	if lower > upper {
+		return 0, errors.New("argument #2 shouldn't be greater than argument #3")
+	}
+
+	ratio := (math.Sqrt(5) - 1) / 2
+	left, right := upper-ratio*(upper-lower), lower+ratio*(upper-lower)
+
+	leftValue, err := function(left)
+	if err != nil {
+		return 0, err
+	}
+
+	rightValue, err := function(right)
+	if err != nil {
+		return 0, err
+	}
+
+	for iteration := 0; iteration < maxBracketingIterations; iteration++ {
+		middle := lower + (upper-lower)/2
+		if upper-lower <= solverTolerance*math.Max(1, math.Abs(middle)) {
+			break
+		}
+
+		if leftValue < rightValue {
+			upper, right, rightValue = right, left, leftValue
+			left = upper - ratio*(upper-lower)
+
+			leftValue, err = function(left)
+		} else {
+			lower, left, leftValue = left, right, rightValue
+			right = lower + ratio*(upper-lower)
+
+			rightValue, err = function(right)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return lower + (upper-lower)/2, nil
+}
@@ -0,0 +1,200 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolvers(test *testing.T) {
+	type args struct {
+		variables models.VariableGroup
+		code      string
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantResult models.Value
+		wantErr    string
+	}{
+		{
+			name: "solve/success",
+			args: args{
+				variables: nil,
+				code:      "solve(x^2 - 2, x, 1)",
+			},
+			wantResult: models.NewNumber(1.414213562),
+			wantErr:    "",
+		},
+		{
+			name: "solve/success with a variable in the expression",
+			args: args{
+				variables: models.VariableGroup{
+					"x": models.NewNumber(100),
+					"y": models.NewNumber(8),
+				},
+				code: "solve(x^3 - y, x, 1) + x",
+			},
+			wantResult: models.NewNumber(102),
+			wantErr:    "",
+		},
+		{
+			name: "solve/success with nested calls",
+			args: args{
+				variables: nil,
+				code:      "solve(solve(y - x, y, 0) - 5, x, 1)",
+			},
+			wantResult: models.NewNumber(5),
+			wantErr:    "",
+		},
+		{
+			name: "solve/success with a linear system",
+			args: args{
+				variables: models.VariableGroup{
+					"a": models.NewArray(
+						models.NewArray(models.NewNumber(2), models.NewNumber(0)),
+						models.NewArray(models.NewNumber(0), models.NewNumber(4)),
+					),
+				},
+				code: "solve(a, [2, 8])[1]",
+			},
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "solve/error with the convergence",
+			args: args{
+				variables: nil,
+				code:      "solve(x^2 + 1, x, 1)",
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:solve ArgumentCount:3} with number #11: " +
+				"solver didn't converge from the guess 1",
+		},
+		{
+			name: "solve/error with the variable",
+			args: args{
+				variables: nil,
+				code:      "solve(x^2 - 2, 2 x, 1)",
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:solve ArgumentCount:3} with number #13: " +
+				"argument #1 should be a variable name",
+		},
+		{
+			name: "solve/error with the expression",
+			args: args{
+				variables: nil,
+				code:      "solve(x - y, x, 1)",
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:solve ArgumentCount:3} with number #9: " +
+				"unable to evaluate the expression for x = 1: " +
+				"unknown variable in command " +
+				"{Kind:1 Operand:y ArgumentCount:0} with number #1",
+		},
+		{
+			name: "solve/error with the expression kind",
+			args: args{
+				variables: nil,
+				code:      `solve("test", x, 1)`,
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:solve ArgumentCount:3} with number #7: " +
+				"expression has type string for x = 1, but number is expected",
+		},
+		{
+			name: "bisect/success",
+			args: args{
+				variables: nil,
+				code:      "bisect(cos(x) - x, x, 0, 1)",
+			},
+			wantResult: models.NewNumber(0.739085133),
+			wantErr:    "",
+		},
+		{
+			name: "bisect/success with a root at the bound",
+			args: args{
+				variables: nil,
+				code:      "bisect(x - 1, x, 1, 2)",
+			},
+			wantResult: models.NewNumber(1),
+			wantErr:    "",
+		},
+		{
+			name: "bisect/error with the signs",
+			args: args{
+				variables: nil,
+				code:      "bisect(x^2 + 1, x, 0, 1)",
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:bisect ArgumentCount:4} with number #12: " +
+				"expression should have different signs at the bounds",
+		},
+		{
+			name: "bisect/error with the range",
+			args: args{
+				variables: nil,
+				code:      "bisect(x, x, 1, 0)",
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:bisect ArgumentCount:4} with number #8: " +
+				"argument #2 shouldn't be greater than argument #3",
+		},
+		{
+			name: "minimize/success",
+			args: args{
+				variables: nil,
+				code:      "minimize((x - 3)^2 + 1, x, 0, 10)",
+			},
+			wantResult: models.NewNumber(3),
+			wantErr:    "",
+		},
+		{
+			name: "minimize/success with a minimum at the bound",
+			args: args{
+				variables: nil,
+				code:      "minimize(x^2, x, 2, 5)",
+			},
+			wantResult: models.NewNumber(2),
+			wantErr:    "",
+		},
+		{
+			name: "minimize/error with the bound kind",
+			args: args{
+				variables: nil,
+				code:      `minimize(x^2, x, 0, "test")`,
+			},
+			wantResult: models.Value{},
+			wantErr: "unable to evaluate the commands: unable to call the function from command " +
+				"{Kind:2 Operand:minimize ArgumentCount:4} with number #10: " +
+				"argument #3 has type string, but number is expected",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotResult, gotErr := models.Value{}, error(nil)
+
+			calculator := NewCalculator(testCase.args.variables, BuiltInFunctions)
+			if gotErr = calculator.Calculate(testCase.args.code); gotErr == nil {
+				gotResult, gotErr = calculator.Finalize()
+			}
+
+			assert.Equal(test, testCase.wantResult.Kind, gotResult.Kind)
+			assert.InDelta(test, testCase.wantResult.Number, gotResult.Number, 1e-6)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package calculator
+
+import (
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+)
+
+// it's a statement of the interpreter translated without evaluating it
+type translatedStatement struct {
+	variable   string // it may contain indexes
+	isConstant bool
+	modeName   string // it's set for the mode statement only
+	// commands of the value, of the indexes and of the arguments
+	// of the print statement; the mode and import statements have no commands
+	commands []models.Command
+}
+
+func translateStatement(
+	input string,
+	functions models.FunctionProvider,
+) (translatedStatement, error) {
+	input = tokenizer.RemoveComment(input)
+
+	var statement translatedStatement
+	var codes []string
+	variable, code := tokenizer.ExtractVariable(input)
+	if constant, ok := tokenizer.ExtractConstant(variable); ok {
+		variable = constant
+		statement.isConstant = true
+	}
+
+	if variable != "" {
+		_, indexesCodes, err := extractIndexes(variable)
+		if err != nil {
+			return translatedStatement{}, err
+		}
+
+		statement.variable = variable
+		codes = indexesCodes
+	} else {
+		if arguments, ok := tokenizer.ExtractKeyword(code, "print"); ok {
+			tokens, err := tokenizeCode(arguments)
+			if err != nil {
+				return translatedStatement{}, err
+			}
+
+			arguments, err := splitArguments(tokens)
+			if err != nil {
+				return translatedStatement{}, err
+			}
+
+			for _, argument := range arguments {
+				commands, err := translateTokens(argument, functions)
+				if err != nil {
+					return translatedStatement{}, err
+				}
+
+				statement.commands = append(statement.commands, commands...)
+			}
+
+			return statement, nil
+		}
+		if modeName, ok := extractModeStatement(code); ok {
+			statement.modeName = modeName
+			return statement, nil
+		}
+		if _, _, ok, err := extractImportStatement(code); ok || err != nil {
+			return statement, err
+		}
+	}
+
+	code, _, _ = tokenizer.SplitAtKeyword(code, "to")
+	for _, code := range append(codes, code) {
+		tokens, err := tokenizeCode(code)
+		if err != nil {
+			return translatedStatement{}, err
+		}
+
+		commands, err := translateTokens(tokens, functions)
+		if err != nil {
+			return translatedStatement{}, err
+		}
+
+		statement.commands = append(statement.commands, commands...)
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,234 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+func mean(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(sumNumbers(numbers) / float64(len(numbers))), nil
+}
+
+func median(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(interpolatePercentile(numbers, 50)), nil
+}
+
+// the smallest one is returned if there are few most frequent numbers
+func statisticalMode(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	sort.Float64s(numbers)
+
+	result, maxCount := numbers[0], 0
+	for start := 0; start < len(numbers); {
+		end := start + 1
+		for end < len(numbers) && numbers[end] == numbers[start] {
+			end++
+		}
+		if end-start > maxCount {
+			result, maxCount = numbers[start], end-start
+		}
+
+		start = end
+	}
+
+	return models.NewNumber(result), nil
+}
+
+func variance(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 2)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	deviationSum := squaredDeviationSum(numbers)
+	return models.NewNumber(deviationSum / float64(len(numbers)-1)), nil
+}
+
+func populationVariance(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	deviationSum := squaredDeviationSum(numbers)
+	return models.NewNumber(deviationSum / float64(len(numbers))), nil
+}
+
+func standardDeviation(arguments []models.Value) (models.Value, error) {
+	result, err := variance(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(math.Sqrt(result.Number)), nil
+}
+
+func populationStandardDeviation(
+	arguments []models.Value,
+) (models.Value, error) {
+	result, err := populationVariance(arguments)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(math.Sqrt(result.Number)), nil
+}
+
+func percentile(arguments []models.Value) (models.Value, error) {
+	err := models.CheckArgumentKinds(arguments, models.NumberValue)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	rank := arguments[0].Number
+	if !(rank >= 0 && rank <= 100) {
+		return models.Value{}, errors.New("argument #0 should be in the range [0, 100]")
+	}
+
+	numbers, err := collectNumbers(arguments, 1, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	return models.NewNumber(interpolatePercentile(numbers, rank)), nil
+}
+
+func minimum(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	result := numbers[0]
+	for _, number := range numbers[1:] {
+		result = math.Min(result, number)
+	}
+
+	return models.NewNumber(result), nil
+}
+
+func maximum(arguments []models.Value) (models.Value, error) {
+	numbers, err := collectNumbers(arguments, 0, 1)
+	if err != nil {
+		return models.Value{}, err
+	}
+
+	result := numbers[0]
+	for _, number := range numbers[1:] {
+		result = math.Max(result, number)
+	}
+
+	return models.NewNumber(result), nil
+}
+
+// it flattens arrays starting from the specified argument, so the arguments
+// may be numbers or (possibly nested) arrays of numbers
+func collectNumbers(
+	arguments []models.Value,
+	firstIndex int,
+	minCount int,
+) ([]float64, error) {
+	numbers := []float64{}
+	for argumentIndex, argument := range arguments {
+		if argumentIndex < firstIndex {
+			continue
+		}
+
+		var err error
+		numbers, err = appendNumbers(numbers, argument, argumentIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(numbers) < minCount {
+		return nil, fmt.Errorf(
+			"%d numbers are given, but at least %d are expected",
+			len(numbers),
+			minCount,
+		)
+	}
+
+	return numbers, nil
+}
+
+func appendNumbers(
+	numbers []float64,
+	value models.Value,
+	argumentIndex int,
+) ([]float64, error) {
+	switch value.Kind {
+	case models.NumberValue:
+		return append(numbers, value.Number), nil
+	case models.ArrayValue:
+		for _, element := range value.Elements {
+			var err error
+			numbers, err = appendNumbers(numbers, element, argumentIndex)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return numbers, nil
+	default:
+		return nil, models.TypeError{
+			ArgumentIndex: argumentIndex,
+			Kind:          value.Kind,
+			WantedKind:    models.NumberValue,
+		}
+	}
+}
+
+func sumNumbers(numbers []float64) float64 {
+	result := 0.0
+	for _, number := range numbers {
+		result += number
+	}
+
+	return result
+}
+
+func squaredDeviationSum(numbers []float64) float64 {
+	average := sumNumbers(numbers) / float64(len(numbers))
+
+	result := 0.0
+	for _, number := range numbers {
+		result += (number - average) * (number - average)
+	}
+
+	return result
+}
+
+// it uses the linear interpolation between the closest ranks
+// like the PERCENTILE.INC function of Excel
+func interpolatePercentile(numbers []float64, rank float64) float64 {
+	sortedNumbers := append([]float64(nil), numbers...)
+	sort.Float64s(sortedNumbers)
+
+	position := rank / 100 * float64(len(sortedNumbers)-1)
+	lowerIndex := int(math.Floor(position))
+	if lowerIndex == len(sortedNumbers)-1 {
+		return sortedNumbers[lowerIndex]
+	}
+
+	fraction := position - float64(lowerIndex)
+	lower, upper := sortedNumbers[lowerIndex], sortedNumbers[lowerIndex+1]
+	return lower + (upper-lower)*fraction
+}
@@ -0,0 +1,256 @@
+package symbolic
+
+import (
+	"fmt"
+	"math"
+)
+
+// derivativeRule returns the derivative of the function
+// by its first argument, which is then multiplied by the derivative
+// of the argument according to the chain rule
+type derivativeRule func(argument Node) Node
+
+// the functions are differentiated in radians, i.e. without the angle mode;
+// piecewise constant functions have the zero derivative
+var derivativeRules = map[string]derivativeRule{
+	"floor": zeroDerivative,
+	"ceil":  zeroDerivative,
+	"trunc": zeroDerivative,
+	"round": zeroDerivative,
+	"sign":  zeroDerivative,
+	"sin": func(argument Node) Node {
+		return NewCall("cos", argument)
+	},
+	"cos": func(argument Node) Node {
+		return negate(NewCall("sin", argument))
+	},
+	"tan": func(argument Node) Node {
+		return divide(NewNumber(1), power(NewCall("cos", argument), NewNumber(2)))
+	},
+	"asin": func(argument Node) Node {
+		return divide(NewNumber(1), NewCall("sqrt", subtract(
+			NewNumber(1),
+			power(argument, NewNumber(2)),
+		)))
+	},
+	"acos": func(argument Node) Node {
+		return negate(divide(NewNumber(1), NewCall("sqrt", subtract(
+			NewNumber(1),
+			power(argument, NewNumber(2)),
+		))))
+	},
+	"atan": func(argument Node) Node {
+		return divide(NewNumber(1), add(NewNumber(1), power(argument, NewNumber(2))))
+	},
+	"sqrt": func(argument Node) Node {
+		return divide(NewNumber(1), multiply(NewNumber(2), NewCall("sqrt", argument)))
+	},
+	"exp": func(argument Node) Node {
+		return NewCall("exp", argument)
+	},
+	"log2": func(argument Node) Node {
+		return divide(NewNumber(1), multiply(argument, NewNumber(math.Ln2)))
+	},
+	"log10": func(argument Node) Node {
+		return divide(NewNumber(1), multiply(argument, NewNumber(math.Ln10)))
+	},
+	"abs": func(argument Node) Node {
+		return NewCall("sign", argument)
+	},
+	"cbrt": func(argument Node) Node {
+		return divide(NewNumber(1), multiply(
+			NewNumber(3),
+			power(NewCall("cbrt", argument), NewNumber(2)),
+		))
+	},
+	"deg": func(argument Node) Node {
+		return NewNumber(180 / math.Pi)
+	},
+	"rad": func(argument Node) Node {
+		return NewNumber(math.Pi / 180)
+	},
+	"sinh": func(argument Node) Node {
+		return NewCall("cosh", argument)
+	},
+	"cosh": func(argument Node) Node {
+		return NewCall("sinh", argument)
+	},
+	"tanh": func(argument Node) Node {
+		return divide(NewNumber(1), power(NewCall("cosh", argument), NewNumber(2)))
+	},
+	"asinh": func(argument Node) Node {
+		return divide(NewNumber(1), NewCall("sqrt", add(
+			power(argument, NewNumber(2)),
+			NewNumber(1),
+		)))
+	},
+	"acosh": func(argument Node) Node {
+		return divide(NewNumber(1), NewCall("sqrt", subtract(
+			power(argument, NewNumber(2)),
+			NewNumber(1),
+		)))
+	},
+	"atanh": func(argument Node) Node {
+		return divide(NewNumber(1), subtract(NewNumber(1), power(argument, NewNumber(2))))
+	},
+	"erf": func(argument Node) Node {
+		return multiply(
+			NewNumber(2/math.SqrtPi),
+			NewCall("exp", negate(power(argument, NewNumber(2)))),
+		)
+	},
+	"erfc": func(argument Node) Node {
+		return negate(multiply(
+			NewNumber(2/math.SqrtPi),
+			NewCall("exp", negate(power(argument, NewNumber(2)))),
+		))
+	},
+}
+
+// Differentiate returns the simplified derivative of the tree
+// by the variable; other variables are considered constants
+func Differentiate(node Node, variable string) (Node, error) {
+	derivative, err := differentiate(node, variable)
+	if err != nil {
+		return Node{}, err
+	}
+
+	return Simplify(derivative), nil
+}
+
+func differentiate(node Node, variable string) (Node, error) {
+	if !node.DependsOn(variable) {
+		return NewNumber(0), nil
+	}
+	if node.Kind == VariableNode {
+		return NewNumber(1), nil
+	}
+
+	derivatives := make([]Node, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		derivative, err := differentiate(argument, variable)
+		if err != nil {
+			return Node{}, err
+		}
+
+		derivatives = append(derivatives, derivative)
+	}
+
+	if _, ok := operatorPrecedences[node.Name]; ok && len(node.Arguments) == 2 {
+		return differentiateOperator(node, derivatives, variable), nil
+	}
+
+	switch {
+	case node.Name == "log" && len(node.Arguments) == 2:
+		// the logarithm to the base is the quotient of natural logarithms
+		return differentiate(divide(
+			NewCall("log", node.Arguments[0]),
+			NewCall("log", node.Arguments[1]),
+		), variable)
+	case node.Name == "log" && len(node.Arguments) == 1:
+		return multiply(divide(NewNumber(1), node.Arguments[0]), derivatives[0]), nil
+	case node.Name == "atan2" && len(node.Arguments) == 2:
+		y, x := node.Arguments[0], node.Arguments[1]
+		return divide(
+			subtract(multiply(x, derivatives[0]), multiply(y, derivatives[1])),
+			add(power(x, NewNumber(2)), power(y, NewNumber(2))),
+		), nil
+	}
+
+	rule, ok := derivativeRules[node.Name]
+	if !ok || len(node.Arguments) != 1 {
+		return Node{}, fmt.Errorf(
+			"unable to differentiate function %s with %d arguments",
+			node.Name,
+			len(node.Arguments),
+		)
+	}
+
+	return multiply(rule(node.Arguments[0]), derivatives[0]), nil
+}
+
+func differentiateOperator(
+	node Node,
+	derivatives []Node,
+	variable string,
+) Node {
+	left, right := node.Arguments[0], node.Arguments[1]
+	leftDerivative, rightDerivative := derivatives[0], derivatives[1]
+
+	switch node.Name {
+	case "+":
+		return add(leftDerivative, rightDerivative)
+	case "-":
+		return subtract(leftDerivative, rightDerivative)
+	case "*":
+		return add(
+			multiply(leftDerivative, right),
+			multiply(left, rightDerivative),
+		)
+	case "/":
+		if !right.DependsOn(variable) {
+			return divide(leftDerivative, right)
+		}
+
+		return divide(
+			subtract(
+				multiply(leftDerivative, right),
+				multiply(left, rightDerivative),
+			),
+			power(right, NewNumber(2)),
+		)
+	case "%":
+		// x % y = x - y * trunc(x / y), and trunc is piecewise constant
+		return subtract(
+			leftDerivative,
+			multiply(rightDerivative, NewCall("trunc", divide(left, right))),
+		)
+	default: // "^"
+		if !right.DependsOn(variable) {
+			return multiply(
+				multiply(right, power(left, subtract(right, NewNumber(1)))),
+				leftDerivative,
+			)
+		}
+		if !left.DependsOn(variable) {
+			return multiply(
+				multiply(node, NewCall("log", left)),
+				rightDerivative,
+			)
+		}
+
+		return multiply(node, add(
+			multiply(rightDerivative, NewCall("log", left)),
+			divide(multiply(right, leftDerivative), left),
+		))
+	}
+}
+
+func zeroDerivative(argument Node) Node {
+	return NewNumber(0)
+}
+
+func add(left Node, right Node) Node {
+	return NewCall("+", left, right)
+}
+
+func subtract(left Node, right Node) Node {
+	return NewCall("-", left, right)
+}
+
+func multiply(left Node, right Node) Node {
+	return NewCall("*", left, right)
+}
+
+func divide(left Node, right Node) Node {
+	return NewCall("/", left, right)
+}
+
+func power(left Node, right Node) Node {
+	return NewCall("^", left, right)
+}
+
+// there are no unary operators, so the negation is a subtraction from zero
+func negate(node Node) Node {
+	return subtract(NewNumber(0), node)
+}
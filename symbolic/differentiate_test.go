@@ -0,0 +1,161 @@
+package symbolic
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/irenicaa/go-calculator/v2/tokenizer"
+	"github.com/irenicaa/go-calculator/v2/translator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDifferentiate(test *testing.T) {
+	type args struct {
+		code     string
+		variable string
+	}
+
+	testsCases := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "constant",
+			args:    args{code: "y^2 + gamma(2)", variable: "x"},
+			want:    "0",
+			wantErr: "",
+		},
+		{
+			name:    "polynomial",
+			args:    args{code: "3 x^2 + 2 x + 1", variable: "x"},
+			want:    "6 * x + 2",
+			wantErr: "",
+		},
+		{
+			name:    "product",
+			args:    args{code: "x * exp(x)", variable: "x"},
+			want:    "exp(x) + x * exp(x)",
+			wantErr: "",
+		},
+		{
+			name:    "product with a constant",
+			args:    args{code: "a * x^2", variable: "x"},
+			want:    "2 * a * x",
+			wantErr: "",
+		},
+		{
+			name:    "quotient",
+			args:    args{code: "sin(x) / x", variable: "x"},
+			want:    "(cos(x) * x - sin(x)) / x^2",
+			wantErr: "",
+		},
+		{
+			name:    "quotient with a constant dividend",
+			args:    args{code: "1 / x", variable: "x"},
+			want:    "0 - 1 / x^2",
+			wantErr: "",
+		},
+		{
+			name:    "power with a variable exponent",
+			args:    args{code: "2^x", variable: "x"},
+			want:    "2^x * log(2)",
+			wantErr: "",
+		},
+		{
+			name:    "power with a variable base and exponent",
+			args:    args{code: "x^x", variable: "x"},
+			want:    "x^x * (log(x) + 1)",
+			wantErr: "",
+		},
+		{
+			name:    "remainder",
+			args:    args{code: "x % 3", variable: "x"},
+			want:    "1",
+			wantErr: "",
+		},
+		{
+			name:    "chain rule",
+			args:    args{code: "sin(x)^2", variable: "x"},
+			want:    "2 * sin(x) * cos(x)",
+			wantErr: "",
+		},
+		{
+			name:    "nested functions",
+			args:    args{code: "sqrt(1 - x^2)", variable: "x"},
+			want:    "0 - x / sqrt(1 - x^2)",
+			wantErr: "",
+		},
+		{
+			name:    "logarithm to the base",
+			args:    args{code: "log(x, 10)", variable: "x"},
+			want:    "1 / (x * log(10))",
+			wantErr: "",
+		},
+		{
+			name:    "function of two arguments",
+			args:    args{code: "atan2(x, 1)", variable: "x"},
+			want:    "1 / (1 + x^2)",
+			wantErr: "",
+		},
+		{
+			name:    "piecewise constant function",
+			args:    args{code: "floor(x) + abs(x)", variable: "x"},
+			want:    "sign(x)",
+			wantErr: "",
+		},
+		{
+			name:    "error",
+			args:    args{code: "gamma(x)", variable: "x"},
+			want:    "",
+			wantErr: "unable to differentiate function gamma with 1 arguments",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			node := parseCode(test, testCase.args.code)
+
+			got, gotErr := Differentiate(node, testCase.args.variable)
+
+			if testCase.wantErr == "" {
+				assert.Equal(test, testCase.want, got.String())
+				assert.NoError(test, gotErr)
+			} else {
+				assert.Equal(test, Node{}, got)
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func parseCode(test *testing.T, code string) Node {
+	tokenizer := tokenizer.Tokenizer{}
+	tokens, err := tokenizer.Tokenize(code)
+	require.NoError(test, err)
+
+	additionalTokens, err := tokenizer.Finalize()
+	require.NoError(test, err)
+	tokens = append(tokens, additionalTokens...)
+
+	functions := models.FunctionNameGroup{}
+	for _, name := range []string{
+		"sin", "cos", "exp", "sqrt", "log", "atan2", "floor", "abs", "gamma",
+	} {
+		functions[name] = models.FunctionSignature{}
+	}
+
+	translator := translator.Translator{}
+	commands, err := translator.Translate(tokens, functions)
+	require.NoError(test, err)
+
+	additionalCommands, err := translator.Finalize()
+	require.NoError(test, err)
+	commands = append(commands, additionalCommands...)
+
+	node, err := Parse(commands)
+	require.NoError(test, err)
+
+	return node
+}
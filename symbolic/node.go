@@ -0,0 +1,225 @@
+package symbolic
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// NodeKind ...
+type NodeKind int
+
+// ...
+const (
+	NumberNode NodeKind = iota
+	VariableNode
+	CallNode // calls of both operators and functions
+)
+
+// Node is a node of an expression tree; the functions of the package
+// make new trees instead of changing arguments, so trees may be shared
+// between goroutines
+type Node struct {
+	Kind      NodeKind
+	Number    float64
+	Name      string // it's used by variables and calls
+	Arguments []Node
+}
+
+// NewNumber ...
+func NewNumber(number float64) Node {
+	return Node{Kind: NumberNode, Number: number}
+}
+
+// NewVariable ...
+func NewVariable(name string) Node {
+	return Node{Kind: VariableNode, Name: name}
+}
+
+// NewCall ...
+func NewCall(name string, arguments ...Node) Node {
+	return Node{Kind: CallNode, Name: name, Arguments: arguments}
+}
+
+// precedences of the binary operators of the calculator;
+// all of them are left-associative
+var operatorPrecedences = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"%": 2,
+	"^": 4,
+}
+
+const atomPrecedence = 5
+
+// Parse builds the expression tree from commands of the translator;
+// only numbers, variables and calls are supported
+func Parse(commands []models.Command) (Node, error) {
+	stack := []Node{}
+	for commandIndex, command := range commands {
+		switch command.Kind {
+		case models.PushNumberCommand:
+			number, err := strconv.ParseFloat(command.Operand, 64)
+			if err != nil {
+				return Node{}, fmt.Errorf(
+					"incorrect number for command %+v with number #%d: %s",
+					command,
+					commandIndex,
+					err,
+				)
+			}
+
+			stack = append(stack, NewNumber(number))
+		case models.PushVariableCommand, models.PushUnitCommand:
+			stack = append(stack, NewVariable(command.Operand))
+		case models.CallFunctionCommand:
+			// operators are binary, and calls of functions
+			// always have the argument count
+			argumentCount := command.ArgumentCount
+			if _, ok := operatorPrecedences[command.Operand]; ok && argumentCount == 0 {
+				argumentCount = 2
+			}
+			if len(stack) < argumentCount {
+				return Node{}, fmt.Errorf(
+					"node stack is empty for argument #%d in command %+v with number #%d",
+					len(stack),
+					command,
+					commandIndex,
+				)
+			}
+
+			arguments := append(
+				[]Node(nil),
+				stack[len(stack)-argumentCount:]...,
+			)
+			stack = stack[:len(stack)-argumentCount]
+
+			stack = append(stack, NewCall(command.Operand, arguments...))
+		default:
+			return Node{}, fmt.Errorf(
+				"unsupported command %+v with number #%d",
+				command,
+				commandIndex,
+			)
+		}
+	}
+	if len(stack) != 1 {
+		return Node{}, errors.New("commands should make a single expression")
+	}
+
+	return stack[0], nil
+}
+
+// Equal checks that the trees are the same structurally
+func (node Node) Equal(other Node) bool {
+	if node.Kind != other.Kind || node.Name != other.Name ||
+		len(node.Arguments) != len(other.Arguments) {
+		return false
+	}
+	if node.Kind == NumberNode && node.Number != other.Number {
+		return false
+	}
+
+	for argumentIndex, argument := range node.Arguments {
+		if !argument.Equal(other.Arguments[argumentIndex]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DependsOn checks that the tree contains the variable
+func (node Node) DependsOn(variable string) bool {
+	if node.Kind == VariableNode {
+		return node.Name == variable
+	}
+
+	for _, argument := range node.Arguments {
+		if argument.DependsOn(variable) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String prints the tree in the calculator syntax with the minimal
+// parentheses; there are no negative literals, so negative numbers
+// are printed as subtractions from zero
+func (node Node) String() string {
+	switch node.Kind {
+	case NumberNode:
+		if node.Number < 0 {
+			return "0 - " + formatNumber(-node.Number)
+		}
+
+		return formatNumber(node.Number)
+	case VariableNode:
+		return node.Name
+	}
+
+	if precedence, ok := operatorPrecedences[node.Name]; ok &&
+		len(node.Arguments) == 2 {
+		left, right := node.Arguments[0], node.Arguments[1]
+
+		leftText := left.String()
+		if left.precedence() < precedence ||
+			// a chain of exponentiations is confusing without parentheses
+			node.Name == "^" && left.precedence() == precedence {
+			leftText = "(" + leftText + ")"
+		}
+
+		rightText := right.String()
+		if right.precedence() <= precedence && !node.isAssociativeWith(right) {
+			rightText = "(" + rightText + ")"
+		}
+
+		if node.Name == "^" {
+			return leftText + node.Name + rightText
+		}
+
+		return leftText + " " + node.Name + " " + rightText
+	}
+
+	arguments := make([]string, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		arguments = append(arguments, argument.String())
+	}
+
+	return node.Name + "(" + strings.Join(arguments, ", ") + ")"
+}
+
+func (node Node) precedence() int {
+	switch {
+	case node.Kind == NumberNode && node.Number < 0:
+		return operatorPrecedences["-"]
+	case node.Kind == CallNode && len(node.Arguments) == 2:
+		if precedence, ok := operatorPrecedences[node.Name]; ok {
+			return precedence
+		}
+	}
+
+	return atomPrecedence
+}
+
+// the right operand doesn't need parentheses if it's the same operation,
+// and the operation is associative
+func (node Node) isAssociativeWith(right Node) bool {
+	return (node.Name == "+" || node.Name == "*") &&
+		right.Kind == CallNode && right.Name == node.Name
+}
+
+func formatNumber(number float64) string {
+	if number == 0 {
+		// it also prints the negative zero as the positive one
+		return "0"
+	}
+
+	return strconv.FormatFloat(number, 'g', -1, 64)
+}
@@ -0,0 +1,191 @@
+package symbolic
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(test *testing.T) {
+	type args struct {
+		commands []models.Command
+	}
+
+	testsCases := []struct {
+		name     string
+		args     args
+		wantNode Node
+		wantErr  string
+	}{
+		{
+			name: "success with operators",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "x"},
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.CallFunctionCommand, Operand: "^"},
+					{Kind: models.PushNumberCommand, Operand: "1"},
+					{Kind: models.CallFunctionCommand, Operand: "-"},
+				},
+			},
+			wantNode: NewCall(
+				"-",
+				NewCall("^", NewVariable("x"), NewNumber(2)),
+				NewNumber(1),
+			),
+			wantErr: "",
+		},
+		{
+			name: "success with functions",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushVariableCommand, Operand: "x"},
+					{Kind: models.PushNumberCommand, Operand: ".5"},
+					{
+						Kind:          models.CallFunctionCommand,
+						Operand:       "atan2",
+						ArgumentCount: 2,
+					},
+					{Kind: models.CallFunctionCommand, Operand: "rand"},
+					{Kind: models.CallFunctionCommand, Operand: "+"},
+				},
+			},
+			wantNode: NewCall(
+				"+",
+				NewCall("atan2", NewVariable("x"), NewNumber(0.5)),
+				NewCall("rand"),
+			),
+			wantErr: "",
+		},
+		{
+			name: "error with a number",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2i"},
+				},
+			},
+			wantNode: Node{},
+			wantErr: "incorrect number for command " +
+				"{Kind:0 Operand:2i ArgumentCount:0} with number #0: " +
+				`strconv.ParseFloat: parsing "2i": invalid syntax`,
+		},
+		{
+			name: "error with an unsupported command",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushStringCommand, Operand: "test"},
+				},
+			},
+			wantNode: Node{},
+			wantErr: "unsupported command " +
+				"{Kind:3 Operand:test ArgumentCount:0} with number #0",
+		},
+		{
+			name: "error with a missed argument",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.CallFunctionCommand, Operand: "*"},
+				},
+			},
+			wantNode: Node{},
+			wantErr: "node stack is empty for argument #1 in command " +
+				"{Kind:2 Operand:* ArgumentCount:0} with number #1",
+		},
+		{
+			name: "error with few expressions",
+			args: args{
+				commands: []models.Command{
+					{Kind: models.PushNumberCommand, Operand: "2"},
+					{Kind: models.PushNumberCommand, Operand: "3"},
+				},
+			},
+			wantNode: Node{},
+			wantErr:  "commands should make a single expression",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotNode, gotErr := Parse(testCase.args.commands)
+
+			assert.Equal(test, testCase.wantNode, gotNode)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestNode_String(test *testing.T) {
+	testsCases := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{
+			name: "number",
+			node: NewNumber(2.5),
+			want: "2.5",
+		},
+		{
+			name: "negative number",
+			node: NewCall("*", NewNumber(2), NewNumber(-3)),
+			want: "2 * (0 - 3)",
+		},
+		{
+			name: "operators with different precedences",
+			node: NewCall(
+				"*",
+				NewCall("+", NewVariable("x"), NewNumber(1)),
+				NewCall("^", NewVariable("x"), NewNumber(2)),
+			),
+			want: "(x + 1) * x^2",
+		},
+		{
+			name: "operators with the same precedence",
+			node: NewCall(
+				"-",
+				NewCall("-", NewVariable("x"), NewNumber(1)),
+				NewCall("-", NewVariable("y"), NewNumber(2)),
+			),
+			want: "x - 1 - (y - 2)",
+		},
+		{
+			name: "associative operators",
+			node: NewCall(
+				"*",
+				NewVariable("x"),
+				NewCall("*", NewVariable("y"), NewVariable("z")),
+			),
+			want: "x * y * z",
+		},
+		{
+			name: "chain of exponentiations",
+			node: NewCall(
+				"^",
+				NewCall("^", NewVariable("x"), NewNumber(2)),
+				NewNumber(3),
+			),
+			want: "(x^2)^3",
+		},
+		{
+			name: "functions",
+			node: NewCall(
+				"atan2",
+				NewCall("sin", NewVariable("x")),
+				NewCall("+", NewVariable("y"), NewNumber(1)),
+			),
+			want: "atan2(sin(x), y + 1)",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.node.String()
+
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
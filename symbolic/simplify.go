@@ -0,0 +1,278 @@
+package symbolic
+
+import "math"
+
+// Simplify folds operations on numbers and applies the basic identities,
+// e.g. x + 0 = x, x * 1 = x and x^1 = x; calls of functions are kept
+func Simplify(node Node) Node {
+	if node.Kind != CallNode {
+		return node
+	}
+
+	arguments := make([]Node, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		arguments = append(arguments, Simplify(argument))
+	}
+	if _, ok := operatorPrecedences[node.Name]; !ok || len(arguments) != 2 {
+		return NewCall(node.Name, arguments...)
+	}
+
+	return simplifyOperator(node.Name, arguments[0], arguments[1])
+}
+
+func simplifyOperator(operator string, left Node, right Node) Node {
+	switch operator {
+	case "+":
+		return simplifySum(left, right)
+	case "-":
+		return simplifyDifference(left, right)
+	case "*":
+		return simplifyProduct(left, right)
+	case "/":
+		return simplifyQuotient(left, right)
+	case "^":
+		return simplifyPower(left, right)
+	default:
+		return NewCall(operator, left, right)
+	}
+}
+
+// only finite results are folded, because they have no literals
+func foldNumbers(operator string, left Node, right Node) (Node, bool) {
+	if left.Kind != NumberNode || right.Kind != NumberNode {
+		return Node{}, false
+	}
+
+	var result float64
+	switch operator {
+	case "+":
+		result = left.Number + right.Number
+	case "-":
+		result = left.Number - right.Number
+	case "*":
+		result = left.Number * right.Number
+	case "/":
+		result = left.Number / right.Number
+	case "^":
+		result = math.Pow(left.Number, right.Number)
+	default:
+		return Node{}, false
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return Node{}, false
+	}
+
+	return NewNumber(result), true
+}
+
+func simplifySum(left Node, right Node) Node {
+	if result, ok := foldNumbers("+", left, right); ok {
+		return result
+	}
+
+	switch {
+	case isNumber(left, 0):
+		return right
+	case isNumber(right, 0):
+		return left
+	case right.Kind == NumberNode && right.Number < 0:
+		return simplifyDifference(left, NewNumber(-right.Number))
+	case isNegation(right):
+		return simplifyDifference(left, right.Arguments[1])
+	case isNegation(left):
+		return simplifyDifference(right, left.Arguments[1])
+	case left.Equal(right):
+		return simplifyProduct(NewNumber(2), left)
+	default:
+		return add(left, right)
+	}
+}
+
+func simplifyDifference(left Node, right Node) Node {
+	if result, ok := foldNumbers("-", left, right); ok {
+		return result
+	}
+
+	switch {
+	case isNumber(right, 0):
+		return left
+	case left.Equal(right):
+		return NewNumber(0)
+	case isNumber(left, 0) && right.Kind == NumberNode:
+		return NewNumber(-right.Number)
+	case isNumber(left, 0) && isNegation(right):
+		return right.Arguments[1]
+	case right.Kind == NumberNode && right.Number < 0:
+		return simplifySum(left, NewNumber(-right.Number))
+	case isNegation(right):
+		return simplifySum(left, right.Arguments[1])
+	default:
+		return subtract(left, right)
+	}
+}
+
+func simplifyProduct(left Node, right Node) Node {
+	if result, ok := foldNumbers("*", left, right); ok {
+		return result
+	}
+
+	switch {
+	case isNumber(left, 0), isNumber(right, 0):
+		return NewNumber(0)
+	case isNumber(left, 1):
+		return right
+	case isNumber(right, 1):
+		return left
+	case right.Kind == NumberNode && left.Kind != NumberNode:
+		// numbers are moved to the left
+		return simplifyProduct(right, left)
+	case left.Kind == NumberNode && left.Number < 0:
+		return simplifyNegation(simplifyProduct(NewNumber(-left.Number), right))
+	case isNegation(left):
+		return simplifyNegation(simplifyProduct(left.Arguments[1], right))
+	case isNegation(right):
+		return simplifyNegation(simplifyProduct(left, right.Arguments[1]))
+	case isQuotient(right):
+		return simplifyQuotient(
+			simplifyProduct(left, right.Arguments[0]),
+			right.Arguments[1],
+		)
+	case isQuotient(left):
+		return simplifyQuotient(
+			simplifyProduct(left.Arguments[0], right),
+			left.Arguments[1],
+		)
+	case left.Kind == NumberNode && isProductWithNumber(right):
+		// the numbers of the operands are joined
+		number, ok := foldNumbers("*", left, right.Arguments[0])
+		if !ok {
+			return multiply(left, right)
+		}
+
+		return simplifyProduct(number, right.Arguments[1])
+	case isProductWithNumber(right):
+		return simplifyProduct(
+			right.Arguments[0],
+			simplifyProduct(left, right.Arguments[1]),
+		)
+	case isProductWithNumber(left) && right.Kind != NumberNode:
+		return simplifyProduct(
+			left.Arguments[0],
+			simplifyProduct(left.Arguments[1], right),
+		)
+	case left.Equal(right):
+		return simplifyPower(left, NewNumber(2))
+	case isPowerWithNumber(left) && left.Arguments[0].Equal(right):
+		return simplifyPower(right, NewNumber(left.Arguments[1].Number+1))
+	case isPowerWithNumber(right) && right.Arguments[0].Equal(left):
+		return simplifyPower(left, NewNumber(right.Arguments[1].Number+1))
+	default:
+		return multiply(left, right)
+	}
+}
+
+func simplifyQuotient(left Node, right Node) Node {
+	if result, ok := foldNumbers("/", left, right); ok {
+		return result
+	}
+
+	switch {
+	case isNumber(left, 0) && right.Kind != NumberNode:
+		return NewNumber(0)
+	case isNumber(right, 1):
+		return left
+	case left.Equal(right):
+		return NewNumber(1)
+	case left.Kind == NumberNode && left.Number < 0:
+		return simplifyNegation(simplifyQuotient(NewNumber(-left.Number), right))
+	case isNegation(left):
+		return simplifyNegation(simplifyQuotient(left.Arguments[1], right))
+	case isNegation(right):
+		return simplifyNegation(simplifyQuotient(left, right.Arguments[1]))
+	case isQuotient(left):
+		return simplifyQuotient(
+			left.Arguments[0],
+			simplifyProduct(left.Arguments[1], right),
+		)
+	case isQuotient(right):
+		return simplifyQuotient(
+			simplifyProduct(left, right.Arguments[1]),
+			right.Arguments[0],
+		)
+	case isPowerWithNumber(right) && right.Arguments[0].Equal(left):
+		return simplifyQuotient(
+			NewNumber(1),
+			simplifyPower(left, NewNumber(right.Arguments[1].Number-1)),
+		)
+	case isPowerWithNumber(left) && left.Arguments[0].Equal(right):
+		return simplifyPower(right, NewNumber(left.Arguments[1].Number-1))
+	case isProductWithNumber(right) ||
+		right.Kind == NumberNode && isProductWithNumber(left):
+		// the number of the divisor is joined with the one of the dividend
+		leftNumber, leftRest := splitNumber(left)
+		rightNumber, rightRest := splitNumber(right)
+		number, ok := foldNumbers("/", leftNumber, rightNumber)
+		if !ok {
+			return divide(left, right)
+		}
+
+		return simplifyProduct(number, simplifyQuotient(leftRest, rightRest))
+	default:
+		return divide(left, right)
+	}
+}
+
+func simplifyPower(left Node, right Node) Node {
+	if result, ok := foldNumbers("^", left, right); ok {
+		return result
+	}
+
+	switch {
+	case isNumber(right, 0), isNumber(left, 1):
+		return NewNumber(1)
+	case isNumber(right, 1):
+		return left
+	default:
+		return power(left, right)
+	}
+}
+
+func simplifyNegation(node Node) Node {
+	return simplifyDifference(NewNumber(0), node)
+}
+
+func isNumber(node Node, number float64) bool {
+	return node.Kind == NumberNode && node.Number == number
+}
+
+func isNegation(node Node) bool {
+	return node.Kind == CallNode && node.Name == "-" &&
+		len(node.Arguments) == 2 && isNumber(node.Arguments[0], 0)
+}
+
+func isQuotient(node Node) bool {
+	return node.Kind == CallNode && node.Name == "/" && len(node.Arguments) == 2
+}
+
+// it returns the number of the product and the rest of it;
+// the number is 1 for other nodes
+func splitNumber(node Node) (number Node, rest Node) {
+	if node.Kind == NumberNode {
+		return node, NewNumber(1)
+	}
+	if isProductWithNumber(node) {
+		return node.Arguments[0], node.Arguments[1]
+	}
+
+	return NewNumber(1), node
+}
+
+func isProductWithNumber(node Node) bool {
+	return node.Kind == CallNode && node.Name == "*" &&
+		len(node.Arguments) == 2 && node.Arguments[0].Kind == NumberNode
+}
+
+func isPowerWithNumber(node Node) bool {
+	return node.Kind == CallNode && node.Name == "^" &&
+		len(node.Arguments) == 2 && node.Arguments[1].Kind == NumberNode
+}
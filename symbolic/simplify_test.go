@@ -0,0 +1,88 @@
+package symbolic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplify(test *testing.T) {
+	x := NewVariable("x")
+
+	testsCases := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{
+			name: "folding of numbers",
+			node: NewCall("+", NewNumber(2), NewCall("*", NewNumber(3), NewNumber(4))),
+			want: "14",
+		},
+		{
+			name: "folding of numbers with an infinite result",
+			node: NewCall("/", NewNumber(1), NewNumber(0)),
+			want: "1 / 0",
+		},
+		{
+			name: "zero terms",
+			node: NewCall("+", NewNumber(0), NewCall("-", x, NewNumber(0))),
+			want: "x",
+		},
+		{
+			name: "unit factors",
+			node: NewCall("*", NewNumber(1), NewCall("/", x, NewNumber(1))),
+			want: "x",
+		},
+		{
+			name: "zero factors",
+			node: NewCall("*", NewCall("sin", x), NewNumber(0)),
+			want: "0",
+		},
+		{
+			name: "equal terms",
+			node: NewCall("+", NewCall("sin", x), NewCall("sin", x)),
+			want: "2 * sin(x)",
+		},
+		{
+			name: "negations",
+			node: NewCall(
+				"+",
+				x,
+				NewCall("*", NewNumber(-2), NewCall("-", NewNumber(0), x)),
+			),
+			want: "x + 2 * x",
+		},
+		{
+			name: "numbers of products",
+			node: NewCall("*", NewCall("*", x, NewNumber(3)), NewCall("*", NewNumber(2), x)),
+			want: "6 * x^2",
+		},
+		{
+			name: "numbers of quotients",
+			node: NewCall("/", NewCall("*", NewNumber(3), x), NewCall("*", NewNumber(6), NewCall("cos", x))),
+			want: "0.5 * x / cos(x)",
+		},
+		{
+			name: "powers",
+			node: NewCall(
+				"*",
+				NewCall("^", x, NewNumber(1)),
+				NewCall("^", NewCall("^", x, NewNumber(0)), NewNumber(2)),
+			),
+			want: "x",
+		},
+		{
+			name: "functions",
+			node: NewCall("sin", NewCall("+", x, NewNumber(0))),
+			want: "sin(x)",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := Simplify(testCase.node).String()
+
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package tokenizer
+
+import "fmt"
+
+// Error is a plain value with the position of the failure.
+type Error struct {
+	Message  string
+	Position int // -1 means the end of the input
+}
+
+// Error ...
+func (err Error) Error() string {
+	return fmt.Sprintf("%s at %s", err.Message, position(err.Position))
+}
+
+func newError(symbolPosition position, format string, arguments ...interface{}) error {
+	return Error{
+		Message:  fmt.Sprintf(format, arguments...),
+		Position: int(symbolPosition),
+	}
+}
@@ -0,0 +1,33 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Error(test *testing.T) {
+	testsCases := []struct {
+		name string
+		err  Error
+		want string
+	}{
+		{
+			name: "with a position",
+			err:  Error{Message: "unknown symbol '@'", Position: 2},
+			want: "unknown symbol '@' at position 2",
+		},
+		{
+			name: "with the end of the input",
+			err:  Error{Message: "empty exponent part", Position: -1},
+			want: "empty exponent part at EOI",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := testCase.err.Error()
+
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ExtractConstant distinguishes the declaration of a constant,
+// e.g. "const x" in "const x = 2", from the assignment of a variable
+// named "const"; it accepts the variable extracted by ExtractVariable.
+func ExtractConstant(variable string) (name string, ok bool) {
+	name, ok = ExtractKeyword(variable, "const")
+	if !ok || name == strings.TrimLeftFunc(name, unicode.IsSpace) {
+		return "", false
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", false
+	}
+
+	return name, true
+}
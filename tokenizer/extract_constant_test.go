@@ -0,0 +1,71 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractConstant(test *testing.T) {
+	type args struct {
+		variable string
+	}
+
+	testsCases := []struct {
+		name     string
+		args     args
+		wantName string
+		wantOk   bool
+	}{
+		{
+			name:     "declaration",
+			args:     args{"const test"},
+			wantName: "test",
+			wantOk:   true,
+		},
+		{
+			name:     "declaration with spaces",
+			args:     args{"  const \t test  "},
+			wantName: "test",
+			wantOk:   true,
+		},
+		{
+			name:     "declaration with indexes",
+			args:     args{"const test[0]"},
+			wantName: "test[0]",
+			wantOk:   true,
+		},
+		{
+			name:     "variable named as the keyword",
+			args:     args{"const"},
+			wantName: "",
+			wantOk:   false,
+		},
+		{
+			name:     "variable with the keyword prefix",
+			args:     args{"constant"},
+			wantName: "",
+			wantOk:   false,
+		},
+		{
+			name:     "variable with the keyword prefix and indexes",
+			args:     args{"const[0]"},
+			wantName: "",
+			wantOk:   false,
+		},
+		{
+			name:     "variable",
+			args:     args{"test"},
+			wantName: "",
+			wantOk:   false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotName, gotOk := ExtractConstant(testCase.args.variable)
+
+			assert.Equal(test, testCase.wantName, gotName)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
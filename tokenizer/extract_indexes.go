@@ -0,0 +1,51 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ExtractIndexes ...
+func ExtractIndexes(variable string) (name string, indexes []string, err error) {
+	separatorIndex := strings.IndexRune(variable, '[')
+	if separatorIndex == -1 {
+		return variable, nil, nil
+	}
+
+	name = strings.TrimSpace(variable[:separatorIndex])
+	indexesPart := variable[separatorIndex:]
+	depth, indexStart := 0, 0
+	for symbolIndex, symbol := range indexesPart {
+		switch {
+		case symbol == '[':
+			if depth == 0 {
+				indexStart = symbolIndex + 1
+			}
+
+			depth++
+		case symbol == ']':
+			depth--
+			if depth < 0 {
+				return "", nil, fmt.Errorf(
+					"missed pair for bracket at %s",
+					position(separatorIndex+symbolIndex),
+				)
+			}
+			if depth == 0 {
+				indexes = append(indexes, indexesPart[indexStart:symbolIndex])
+			}
+		case depth == 0 && !unicode.IsSpace(symbol):
+			return "", nil, fmt.Errorf(
+				"unexpected symbol %q at %s",
+				symbol,
+				position(separatorIndex+symbolIndex),
+			)
+		}
+	}
+	if depth != 0 {
+		return "", nil, fmt.Errorf("missed pair for bracket at %s", eoi)
+	}
+
+	return name, indexes, nil
+}
@@ -0,0 +1,84 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractIndexes(test *testing.T) {
+	type args struct {
+		variable string
+	}
+
+	testsCases := []struct {
+		name        string
+		args        args
+		wantName    string
+		wantIndexes []string
+		wantErr     string
+	}{
+		{
+			name:        "without indexes",
+			args:        args{variable: "test"},
+			wantName:    "test",
+			wantIndexes: nil,
+			wantErr:     "",
+		},
+		{
+			name:        "with an index",
+			args:        args{variable: "test [i + 1]"},
+			wantName:    "test",
+			wantIndexes: []string{"i + 1"},
+			wantErr:     "",
+		},
+		{
+			name:        "with few indexes",
+			args:        args{variable: "test[i] [j]"},
+			wantName:    "test",
+			wantIndexes: []string{"i", "j"},
+			wantErr:     "",
+		},
+		{
+			name:        "with nested indexes",
+			args:        args{variable: "test[other[i]]"},
+			wantName:    "test",
+			wantIndexes: []string{"other[i]"},
+			wantErr:     "",
+		},
+		{
+			name:        "error with an extra right bracket",
+			args:        args{variable: "test[i]]"},
+			wantName:    "",
+			wantIndexes: nil,
+			wantErr:     "missed pair for bracket at position 7",
+		},
+		{
+			name:        "error with a missed right bracket",
+			args:        args{variable: "test[i"},
+			wantName:    "",
+			wantIndexes: nil,
+			wantErr:     "missed pair for bracket at EOI",
+		},
+		{
+			name:        "error with an unexpected symbol",
+			args:        args{variable: "test[i]j"},
+			wantName:    "",
+			wantIndexes: nil,
+			wantErr:     "unexpected symbol 'j' at position 7",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotName, gotIndexes, gotErr := ExtractIndexes(testCase.args.variable)
+
+			assert.Equal(test, testCase.wantName, gotName)
+			assert.Equal(test, testCase.wantIndexes, gotIndexes)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
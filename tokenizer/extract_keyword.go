@@ -0,0 +1,23 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExtractKeyword ...
+func ExtractKeyword(input string, keyword string) (code string, ok bool) {
+	trimmedInput := strings.TrimLeftFunc(input, unicode.IsSpace)
+	if !strings.HasPrefix(trimmedInput, keyword) {
+		return input, false
+	}
+
+	code = trimmedInput[len(keyword):]
+	nextSymbol, _ := utf8.DecodeRuneInString(code)
+	if isIdentifierSymbol(nextSymbol) {
+		return input, false
+	}
+
+	return code, true
+}
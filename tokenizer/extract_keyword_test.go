@@ -0,0 +1,75 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractKeyword(test *testing.T) {
+	type args struct {
+		input   string
+		keyword string
+	}
+
+	testsCases := []struct {
+		name     string
+		args     args
+		wantCode string
+		wantOk   bool
+	}{
+		{
+			name:     "string with keyword",
+			args:     args{input: "  print 2 + 3", keyword: "print"},
+			wantCode: " 2 + 3",
+			wantOk:   true,
+		},
+		{
+			name:     "string with keyword and string literal",
+			args:     args{input: `print"test"`, keyword: "print"},
+			wantCode: `"test"`,
+			wantOk:   true,
+		},
+		{
+			name:     "string with keyword only",
+			args:     args{input: "print", keyword: "print"},
+			wantCode: "",
+			wantOk:   true,
+		},
+		{
+			name:     "string with identifier starting with keyword",
+			args:     args{input: "printer + 1", keyword: "print"},
+			wantCode: "printer + 1",
+			wantOk:   false,
+		},
+		{
+			name:     "string with dotted identifier starting with keyword",
+			args:     args{input: "print.x + 1", keyword: "print"},
+			wantCode: "print.x + 1",
+			wantOk:   false,
+		},
+		{
+			name:     "string without keyword",
+			args:     args{input: "2 + 3", keyword: "print"},
+			wantCode: "2 + 3",
+			wantOk:   false,
+		},
+		{
+			name:     "empty string",
+			args:     args{input: "", keyword: "print"},
+			wantCode: "",
+			wantOk:   false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotCode, gotOk := ExtractKeyword(
+				testCase.args.input,
+				testCase.args.keyword,
+			)
+
+			assert.Equal(test, testCase.wantCode, gotCode)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
@@ -41,6 +41,12 @@ func TestExtractVariable(test *testing.T) {
 			wantVariable: "test1",
 			wantCode:     " test2 = test3 + 1",
 		},
+		{
+			name:         "string with separator inside string literal",
+			args:         args{`"test1 = test2"`},
+			wantVariable: "",
+			wantCode:     `"test1 = test2"`,
+		},
 		{
 			name:         "empty string",
 			args:         args{""},
@@ -0,0 +1,22 @@
+package tokenizer
+
+import "strings"
+
+// it skips the contents of string literals
+func indexOutsideStrings(input string, separator string) int {
+	isString, isEscape := false, false
+	for symbolIndex, symbol := range input {
+		switch {
+		case isEscape:
+			isEscape = false
+		case isString && symbol == '\\':
+			isEscape = true
+		case symbol == '"':
+			isString = !isString
+		case !isString && strings.HasPrefix(input[symbolIndex:], separator):
+			return symbolIndex
+		}
+	}
+
+	return -1
+}
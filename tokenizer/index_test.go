@@ -0,0 +1,63 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexOutsideStrings(test *testing.T) {
+	type args struct {
+		input     string
+		separator string
+	}
+
+	testsCases := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "separator without strings",
+			args: args{input: "one = two", separator: "="},
+			want: 4,
+		},
+		{
+			name: "separator after string",
+			args: args{input: `"one" = two`, separator: "="},
+			want: 6,
+		},
+		{
+			name: "separator inside string",
+			args: args{input: `"one = two"`, separator: "="},
+			want: -1,
+		},
+		{
+			name: "separator inside and outside string",
+			args: args{input: `"one // two" // three`, separator: "//"},
+			want: 13,
+		},
+		{
+			name: "separator after string with escaped quote",
+			args: args{input: `"one \" = two" = three`, separator: "="},
+			want: 15,
+		},
+		{
+			name: "separator inside unterminated string",
+			args: args{input: `"one = two`, separator: "="},
+			want: -1,
+		},
+		{
+			name: "without separator",
+			args: args{input: "one", separator: "="},
+			want: -1,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			got := indexOutsideStrings(testCase.args.input, testCase.args.separator)
+
+			assert.Equal(test, testCase.want, got)
+		})
+	}
+}
@@ -1,10 +1,8 @@
 package tokenizer
 
-import "strings"
-
 // RemoveComment ...
 func RemoveComment(input string) string {
-	if separatorIndex := strings.Index(input, "//"); separatorIndex != -1 {
+	if separatorIndex := indexOutsideStrings(input, "//"); separatorIndex != -1 {
 		input = input[:separatorIndex]
 	}
 
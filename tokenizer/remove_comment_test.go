@@ -21,6 +21,11 @@ func TestRemoveComment(test *testing.T) {
 			args: args{input: "test1 // test2"},
 			want: "test1 ",
 		},
+		{
+			name: "string with comment and string literal",
+			args: args{input: `"test1 // test2" // test3`},
+			want: `"test1 // test2" `,
+		},
 		{
 			name: "string without comment",
 			args: args{input: "test1"},
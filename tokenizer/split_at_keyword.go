@@ -0,0 +1,46 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitAtKeyword splits the input at the last occurrence of the keyword
+// that is a separate word outside string literals.
+func SplitAtKeyword(
+	input string,
+	keyword string,
+) (before string, after string, ok bool) {
+	keywordIndex := -1
+	isString, isEscape := false, false
+	for symbolIndex, symbol := range input {
+		switch {
+		case isEscape:
+			isEscape = false
+		case isString && symbol == '\\':
+			isEscape = true
+		case symbol == '"':
+			isString = !isString
+		case !isString && strings.HasPrefix(input[symbolIndex:], keyword):
+			previousSymbol, _ := utf8.DecodeLastRuneInString(input[:symbolIndex])
+			nextSymbol, _ :=
+				utf8.DecodeRuneInString(input[symbolIndex+len(keyword):])
+			if !isIdentifierSymbol(previousSymbol) &&
+				!isIdentifierSymbol(nextSymbol) {
+				keywordIndex = symbolIndex
+			}
+		}
+	}
+	if keywordIndex == -1 {
+		return input, "", false
+	}
+
+	return input[:keywordIndex], input[keywordIndex+len(keyword):], true
+}
+
+// the dot is included because of the dotted identifiers of the namespaces
+func isIdentifierSymbol(symbol rune) bool {
+	return unicode.IsLetter(symbol) || unicode.IsDigit(symbol) ||
+		symbol == '_' || symbol == '.'
+}
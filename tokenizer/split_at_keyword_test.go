@@ -0,0 +1,75 @@
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitAtKeyword(test *testing.T) {
+	type args struct {
+		input   string
+		keyword string
+	}
+
+	testsCases := []struct {
+		name       string
+		args       args
+		wantBefore string
+		wantAfter  string
+		wantOk     bool
+	}{
+		{
+			name:       "string with keyword",
+			args:       args{input: "5 GiB / 2 s to MB/s", keyword: "to"},
+			wantBefore: "5 GiB / 2 s ",
+			wantAfter:  " MB/s",
+			wantOk:     true,
+		},
+		{
+			name:       "string with several keywords",
+			args:       args{input: "2 to 3 to m", keyword: "to"},
+			wantBefore: "2 to 3 ",
+			wantAfter:  " m",
+			wantOk:     true,
+		},
+		{
+			name:       "string with keyword in string literal",
+			args:       args{input: `len("a to b")`, keyword: "to"},
+			wantBefore: `len("a to b")`,
+			wantAfter:  "",
+			wantOk:     false,
+		},
+		{
+			name:       "string with identifiers containing keyword",
+			args:       args{input: "total + to_m + auto", keyword: "to"},
+			wantBefore: "total + to_m + auto",
+			wantAfter:  "",
+			wantOk:     false,
+		},
+		{
+			name:       "string with dotted identifiers containing keyword",
+			args:       args{input: "a.to * 2 + to.b", keyword: "to"},
+			wantBefore: "a.to * 2 + to.b",
+			wantAfter:  "",
+			wantOk:     false,
+		},
+		{
+			name:       "string without keyword",
+			args:       args{input: "2 + 3", keyword: "to"},
+			wantBefore: "2 + 3",
+			wantAfter:  "",
+			wantOk:     false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotBefore, gotAfter, gotOk :=
+				SplitAtKeyword(testCase.args.input, testCase.args.keyword)
+
+			assert.Equal(test, testCase.wantBefore, gotBefore)
+			assert.Equal(test, testCase.wantAfter, gotAfter)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package tokenizer
 
 import (
-	"fmt"
 	"strings"
 	"unicode"
 
@@ -15,20 +14,70 @@ const (
 	integerPartTokenizerState
 	fractionalPartTokenizerState
 	exponentTokenizerState
+	imaginaryUnitTokenizerState
 	identifierTokenizerState
+	identifierDotTokenizerState
+	stringTokenizerState
+	stringEscapeTokenizerState
 )
 
-// Tokenizer ...
+// Tokenizer keeps the state between parts of the code,
+// so it isn't safe for concurrent use.
 type Tokenizer struct {
 	tokens []models.Token
 	state  tokenizerState
 	buffer string
+	// it's the position of the last dot of the identifier,
+	// because a dot should be followed by the next part of the identifier
+	dotPosition position
 }
 
 // Tokenize ...
 func (tokenizer *Tokenizer) Tokenize(code string) ([]models.Token, error) {
 	for symbolIndex, symbol := range code {
 		symbolPosition := position(symbolIndex)
+		switch tokenizer.state {
+		case stringTokenizerState:
+			switch symbol {
+			case '"':
+				tokenizer.addTokenFromBuffer(models.StringToken)
+				tokenizer.state = defaultTokenizerState
+			case '\\':
+				tokenizer.state = stringEscapeTokenizerState
+			default:
+				tokenizer.buffer += string(symbol)
+			}
+
+			continue
+		case stringEscapeTokenizerState:
+			unescapedSymbol, ok := unescapeSymbol(symbol)
+			if !ok {
+				return nil, newError(
+					symbolPosition,
+					"unknown escape sequence \"\\%c\"",
+					symbol,
+				)
+			}
+
+			tokenizer.state = stringTokenizerState
+			tokenizer.buffer += string(unescapedSymbol)
+			continue
+		}
+
+		if tokenizer.state == imaginaryUnitTokenizerState &&
+			(unicode.IsLetter(symbol) || unicode.IsDigit(symbol) || symbol == '_') {
+			return nil, newError(
+				symbolPosition,
+				"unexpected symbol %q after the imaginary unit",
+				symbol,
+			)
+		}
+
+		if tokenizer.state == identifierDotTokenizerState &&
+			!unicode.IsLetter(symbol) && symbol != '_' {
+			return nil, newError(tokenizer.dotPosition, "unexpected fractional point")
+		}
+
 		switch {
 		case unicode.IsDigit(symbol):
 			if tokenizer.state == defaultTokenizerState {
@@ -43,8 +92,17 @@ func (tokenizer *Tokenizer) Tokenize(code string) ([]models.Token, error) {
 					tokenizer.buffer += string(symbol)
 					continue
 				}
+
+				fallthrough
+			case exponentTokenizerState:
+				if symbol == 'i' && !tokenizer.isNumberIncomplete() {
+					tokenizer.state = imaginaryUnitTokenizerState
+					tokenizer.buffer += string(symbol)
+					continue
+				}
 			}
-			if tokenizer.state != identifierTokenizerState {
+			if tokenizer.state != identifierTokenizerState &&
+				tokenizer.state != identifierDotTokenizerState {
 				if err := tokenizer.resetBuffer(symbolPosition); err != nil {
 					return nil, err
 				}
@@ -65,7 +123,7 @@ func (tokenizer *Tokenizer) Tokenize(code string) ([]models.Token, error) {
 			}
 
 			fallthrough
-		case strings.ContainsRune("*/%^(),", symbol):
+		case strings.ContainsRune("*/%^(),[]", symbol):
 			if err := tokenizer.resetBuffer(symbolPosition); err != nil {
 				return nil, err
 			}
@@ -77,11 +135,23 @@ func (tokenizer *Tokenizer) Tokenize(code string) ([]models.Token, error) {
 				tokenizer.state = fractionalPartTokenizerState
 				tokenizer.buffer += string(symbol)
 				continue
+			case identifierTokenizerState:
+				// it separates parts of a namespaced identifier, e.g. "geo.radius"
+				tokenizer.state = identifierDotTokenizerState
+				tokenizer.buffer += string(symbol)
+				tokenizer.dotPosition = symbolPosition
+				continue
+			}
+
+			return nil, newError(symbolPosition, "unexpected fractional point")
+		case symbol == '"':
+			if err := tokenizer.resetBuffer(symbolPosition); err != nil {
+				return nil, err
 			}
 
-			return nil, fmt.Errorf("unexpected fractional point at %s", symbolPosition)
+			tokenizer.state = stringTokenizerState
 		default:
-			return nil, fmt.Errorf("unknown symbol %q at %s", symbol, symbolPosition)
+			return nil, newError(symbolPosition, "unknown symbol %q", symbol)
 		}
 	}
 
@@ -100,6 +170,14 @@ func (tokenizer *Tokenizer) Finalize() ([]models.Token, error) {
 	return tokenizer.tokens, nil
 }
 
+func (tokenizer Tokenizer) isNumberIncomplete() bool {
+	if tokenizer.state == exponentTokenizerState {
+		return tokenizer.isExponentEmpty()
+	}
+
+	return tokenizer.areIntegerAndFractionalEmpty()
+}
+
 func (tokenizer Tokenizer) areIntegerAndFractionalEmpty() bool {
 	return tokenizer.buffer == "."
 }
@@ -129,22 +207,41 @@ func (tokenizer *Tokenizer) resetBuffer(symbolIndex position) error {
 	switch tokenizer.state {
 	case integerPartTokenizerState, fractionalPartTokenizerState:
 		if tokenizer.areIntegerAndFractionalEmpty() {
-			return fmt.Errorf(
-				"both integer and fractional parts are empty at %s",
+			return newError(
 				symbolIndex,
+				"both integer and fractional parts are empty",
 			)
 		}
 
 		tokenizer.addTokenFromBuffer(models.NumberToken)
 	case exponentTokenizerState:
 		if tokenizer.isExponentEmpty() {
-			return fmt.Errorf("empty exponent part at %s", symbolIndex)
+			return newError(symbolIndex, "empty exponent part")
 		}
 
+		tokenizer.addTokenFromBuffer(models.NumberToken)
+	case imaginaryUnitTokenizerState:
 		tokenizer.addTokenFromBuffer(models.NumberToken)
 	case identifierTokenizerState:
 		tokenizer.addTokenFromBuffer(models.IdentifierToken)
+	case identifierDotTokenizerState:
+		return newError(tokenizer.dotPosition, "unexpected fractional point")
+	case stringTokenizerState, stringEscapeTokenizerState:
+		return newError(symbolIndex, "unterminated string literal")
 	}
 
 	return nil
 }
+
+func unescapeSymbol(symbol rune) (rune, bool) {
+	switch symbol {
+	case 'n':
+		return '\n', true
+	case 't':
+		return '\t', true
+	case '"', '\\':
+		return symbol, true
+	default:
+		return 0, false
+	}
+}
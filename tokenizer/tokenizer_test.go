@@ -74,6 +74,54 @@ func TestTokenizer(test *testing.T) {
 			wantErr:    "",
 		},
 
+		// imaginary number
+		{
+			name:       "imaginary integer",
+			args:       args{code: "23i"},
+			wantTokens: []models.Token{{Kind: models.NumberToken, Value: "23i"}},
+			wantErr:    "",
+		},
+		{
+			name:       "imaginary fractional",
+			args:       args{code: "23.42i"},
+			wantTokens: []models.Token{{Kind: models.NumberToken, Value: "23.42i"}},
+			wantErr:    "",
+		},
+		{
+			name:       "imaginary exponent",
+			args:       args{code: "23.42e-10i"},
+			wantTokens: []models.Token{{Kind: models.NumberToken, Value: "23.42e-10i"}},
+			wantErr:    "",
+		},
+		{
+			name: "imaginary with real",
+			args: args{code: "3+4i"},
+			wantTokens: []models.Token{
+				{Kind: models.NumberToken, Value: "3"},
+				{Kind: models.PlusToken, Value: "+"},
+				{Kind: models.NumberToken, Value: "4i"},
+			},
+			wantErr: "",
+		},
+		{
+			name:       "imaginary with error (integer and fractional parts are empty)",
+			args:       args{code: ".i"},
+			wantTokens: nil,
+			wantErr:    "both integer and fractional parts are empty at position 1",
+		},
+		{
+			name:       "imaginary with error (exponent part are empty)",
+			args:       args{code: "23ei"},
+			wantTokens: nil,
+			wantErr:    "empty exponent part at position 3",
+		},
+		{
+			name:       "imaginary with error (symbol after the imaginary unit)",
+			args:       args{code: "23in"},
+			wantTokens: nil,
+			wantErr:    "unexpected symbol 'n' after the imaginary unit at position 3",
+		},
+
 		// identifier
 		{
 			name:       "identifier",
@@ -120,6 +168,45 @@ func TestTokenizer(test *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name:       "identifier with dots",
+			args:       args{code: "geo.earth_2.radius"},
+			wantTokens: []models.Token{{Kind: models.IdentifierToken, Value: "geo.earth_2.radius"}},
+			wantErr:    "",
+		},
+		{
+			name: "identifier with dots and a call",
+			args: args{code: "finance.pmt(2)"},
+			wantTokens: []models.Token{
+				{Kind: models.IdentifierToken, Value: "finance.pmt"},
+				{Kind: models.LeftParenthesisToken, Value: "("},
+				{Kind: models.NumberToken, Value: "2"},
+				{Kind: models.RightParenthesisToken, Value: ")"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "identifier with dots and an underscore",
+			args: args{code: "geo._radius+2"},
+			wantTokens: []models.Token{
+				{Kind: models.IdentifierToken, Value: "geo._radius"},
+				{Kind: models.PlusToken, Value: "+"},
+				{Kind: models.NumberToken, Value: "2"},
+			},
+			wantErr: "",
+		},
+		{
+			name:       "identifier with error (dot before an integer)",
+			args:       args{code: "geo.2"},
+			wantTokens: nil,
+			wantErr:    "unexpected fractional point at position 3",
+		},
+		{
+			name:       "identifier with error (several dots)",
+			args:       args{code: "geo..radius"},
+			wantTokens: nil,
+			wantErr:    "unexpected fractional point at position 3",
+		},
 		{
 			name:       "identifier with error (integer and fractional parts are empty)",
 			args:       args{code: ".test"},
@@ -658,6 +745,91 @@ func TestTokenizer(test *testing.T) {
 			wantErr:    "empty exponent part at position 3",
 		},
 
+		// brackets
+		{
+			name: "brackets with integers",
+			args: args{code: "test[23]"},
+			wantTokens: []models.Token{
+				{Kind: models.IdentifierToken, Value: "test"},
+				{Kind: models.LeftBracketToken, Value: "["},
+				{Kind: models.NumberToken, Value: "23"},
+				{Kind: models.RightBracketToken, Value: "]"},
+			},
+			wantErr: "",
+		},
+		{
+			name:       "right bracket with error (exponent part are empty)",
+			args:       args{code: "test[23e]"},
+			wantTokens: nil,
+			wantErr:    "empty exponent part at position 8",
+		},
+
+		// string
+		{
+			name:       "string",
+			args:       args{code: `"test"`},
+			wantTokens: []models.Token{{Kind: models.StringToken, Value: "test"}},
+			wantErr:    "",
+		},
+		{
+			name:       "empty string",
+			args:       args{code: `""`},
+			wantTokens: []models.Token{{Kind: models.StringToken, Value: ""}},
+			wantErr:    "",
+		},
+		{
+			name: "string with special symbols",
+			args: args{code: `"one + two, (three) // four"`},
+			wantTokens: []models.Token{
+				{Kind: models.StringToken, Value: "one + two, (three) // four"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "string with escape sequences",
+			args: args{code: `"one\ttwo\n\"three\" \\ four"`},
+			wantTokens: []models.Token{
+				{Kind: models.StringToken, Value: "one\ttwo\n\"three\" \\ four"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "string with other tokens",
+			args: args{code: `23"test"one,"two"`},
+			wantTokens: []models.Token{
+				{Kind: models.NumberToken, Value: "23"},
+				{Kind: models.StringToken, Value: "test"},
+				{Kind: models.IdentifierToken, Value: "one"},
+				{Kind: models.CommaToken, Value: ","},
+				{Kind: models.StringToken, Value: "two"},
+			},
+			wantErr: "",
+		},
+		{
+			name:       "string with error (integer and fractional parts are empty)",
+			args:       args{code: `."test"`},
+			wantTokens: nil,
+			wantErr:    "both integer and fractional parts are empty at position 1",
+		},
+		{
+			name:       "string with error (unknown escape sequence)",
+			args:       args{code: `"test\q"`},
+			wantTokens: nil,
+			wantErr:    "unknown escape sequence \"\\q\" at position 6",
+		},
+		{
+			name:       "string with error (unterminated string literal)",
+			args:       args{code: `"test`},
+			wantTokens: nil,
+			wantErr:    "unterminated string literal at EOI",
+		},
+		{
+			name:       "string with error (unterminated escape sequence)",
+			args:       args{code: `"test\`},
+			wantTokens: nil,
+			wantErr:    "unterminated string literal at EOI",
+		},
+
 		// misc. errors
 		{
 			name:       "error with a fractional point after fractional part",
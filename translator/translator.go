@@ -3,6 +3,7 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/irenicaa/go-calculator/v2/models"
 	"github.com/irenicaa/go-calculator/v2/models/containers"
@@ -13,48 +14,121 @@ var errStopAndRestore = errors.New("stop and restore")
 
 type stackChecker func(tokenOnStack models.Token, ok bool) error
 
-// Translator ...
+// it's used for both brackets and parentheses
+type bracket struct {
+	isLiteral    bool
+	elementCount int
+	// it's specified for calls of functions with unevaluated arguments
+	lazyArgumentCount int
+}
+
+// Translator keeps the operator stack between parts of the tokens,
+// so it isn't safe for concurrent use.
 type Translator struct {
-	commands []models.Command
-	stack    containers.TokenStack
+	commands     []models.Command
+	stack        containers.TokenStack
+	brackets     []bracket
+	afterOperand bool
+	afterNumber  bool
+	// the function name on the stack is a call
+	// only if it's followed by a parenthesis
+	afterFunctionName bool
+	// the function name on the stack follows a number,
+	// so it's a unit if it isn't a call
+	isFunctionNameAfterNumber bool
 }
 
 // Translate ...
 func (translator *Translator) Translate(
 	tokens []models.Token,
-	functions models.FunctionNameGroup,
+	functions models.FunctionProvider,
 ) ([]models.Command, error) {
 	for tokenIndex, token := range tokens {
+		afterFunctionName := translator.afterFunctionName
+		if afterFunctionName && token.Kind != models.LeftParenthesisToken {
+			translator.completeVariable()
+		}
+		translator.afterFunctionName = false
+
+		afterOperand, afterNumber := translator.afterOperand, translator.afterNumber
+		translator.afterOperand, translator.afterNumber = false, false
+
+		if len(translator.brackets) != 0 {
+			lastBracket := &translator.brackets[len(translator.brackets)-1]
+			if lastBracket.elementCount == 0 &&
+				token.Kind != models.RightBracketToken &&
+				token.Kind != models.RightParenthesisToken {
+				lastBracket.elementCount = 1
+			}
+		}
+
 		switch {
 		case token.Kind == models.NumberToken:
 			translator.addCommand(models.PushNumberCommand, token)
+			translator.afterOperand, translator.afterNumber = true, true
+		case token.Kind == models.StringToken:
+			translator.addCommand(models.PushStringCommand, token)
+			translator.afterOperand = true
 		case token.Kind == models.IdentifierToken:
-			if _, ok := functions[token.Value]; ok {
+			if afterNumber {
+				translator.pushOperator(models.Token{
+					Kind:  models.ImplicitMultiplicationToken,
+					Value: "*",
+				})
+			}
+
+			if _, ok := functions.Function(token.Value); ok {
 				translator.stack.Push(token)
+				translator.afterFunctionName = true
+				translator.isFunctionNameAfterNumber = afterNumber
 				continue
 			}
 
-			translator.addCommand(models.PushVariableCommand, token)
-		case token.Kind.IsOperator():
-			// in this case, all errors will be processed inside the method
-			translator.unwindStack(func(tokenOnStack models.Token, ok bool) error {
-				if !ok {
-					return errStop
-				}
-				if !tokenOnStack.Kind.IsOperator() {
-					return errStopAndRestore
-				}
-				if tokenOnStack.Kind.Precedence() < token.Kind.Precedence() {
-					return errStopAndRestore
-				}
+			kind := models.PushVariableCommand
+			if afterNumber {
+				kind = models.PushUnitCommand
+			}
 
-				return nil
-			})
+			translator.addCommand(kind, token)
+			translator.afterOperand = true
+		case token.Kind.IsOperator():
+			// all the operators are binary, so they follow an operand
+			if !afterOperand {
+				return nil, fmt.Errorf(
+					"unexpected token %+v with number #%d",
+					token,
+					tokenIndex,
+				)
+			}
 
-			translator.stack.Push(token)
+			translator.pushOperator(token)
 		case token.Kind == models.LeftParenthesisToken:
+			var lazyArgumentCount int
+			if afterFunctionName {
+				tokenOnStack, _ := translator.stack.Pop()
+				translator.stack.Push(tokenOnStack)
+
+				function, _ := functions.Function(tokenOnStack.Value)
+				lazyArgumentCount = function.LazyArgumentCount
+			}
+
 			translator.stack.Push(token)
+			translator.brackets = append(
+				translator.brackets,
+				bracket{lazyArgumentCount: lazyArgumentCount},
+			)
+			translator.startLazyArgument(0)
 		case token.Kind == models.RightParenthesisToken:
+			if len(translator.brackets) != 0 &&
+				translator.brackets[len(translator.brackets)-1].elementCount != 0 &&
+				!afterOperand {
+				return nil, fmt.Errorf(
+					"unexpected token %+v with number #%d",
+					token,
+					tokenIndex,
+				)
+			}
+
 			err := translator.unwindStack(
 				func(tokenOnStack models.Token, ok bool) error {
 					if !ok {
@@ -67,6 +141,59 @@ func (translator *Translator) Translate(
 					if tokenOnStack.Kind == models.LeftParenthesisToken {
 						return errStop
 					}
+					if tokenOnStack.Kind == models.LeftBracketToken {
+						return fmt.Errorf(
+							"missed pair for token %+v with number #%d",
+							token,
+							tokenIndex,
+						)
+					}
+
+					return nil
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			translator.completeLazyArgument()
+
+			lastBracket := translator.brackets[len(translator.brackets)-1]
+			translator.brackets = translator.brackets[:len(translator.brackets)-1]
+
+			translator.completeFunctionCall(lastBracket.elementCount)
+			translator.afterOperand = true
+		case token.Kind == models.LeftBracketToken:
+			// a bracket after an operand is an index,
+			// otherwise it starts an array literal
+			translator.stack.Push(token)
+			translator.brackets = append(
+				translator.brackets,
+				bracket{isLiteral: !afterOperand},
+			)
+		case token.Kind == models.RightBracketToken:
+			if len(translator.brackets) != 0 &&
+				translator.brackets[len(translator.brackets)-1].elementCount != 0 &&
+				!afterOperand {
+				return nil, fmt.Errorf(
+					"unexpected token %+v with number #%d",
+					token,
+					tokenIndex,
+				)
+			}
+
+			err := translator.unwindStack(
+				func(tokenOnStack models.Token, ok bool) error {
+					if !ok || tokenOnStack.Kind == models.LeftParenthesisToken {
+						return fmt.Errorf(
+							"missed pair for token %+v with number #%d",
+							token,
+							tokenIndex,
+						)
+					}
+					if tokenOnStack.Kind == models.LeftBracketToken {
+						return errStop
+					}
 
 					return nil
 				},
@@ -74,7 +201,37 @@ func (translator *Translator) Translate(
 			if err != nil {
 				return nil, err
 			}
+
+			lastBracket := translator.brackets[len(translator.brackets)-1]
+			translator.brackets = translator.brackets[:len(translator.brackets)-1]
+
+			command := models.Command{
+				Kind:    models.MakeArrayCommand,
+				Operand: strconv.Itoa(lastBracket.elementCount),
+			}
+			if !lastBracket.isLiteral {
+				if lastBracket.elementCount != 1 {
+					return nil, fmt.Errorf(
+						"index should be a single expression for token %+v with number #%d",
+						token,
+						tokenIndex,
+					)
+				}
+
+				command = models.Command{Kind: models.IndexCommand}
+			}
+
+			translator.commands = append(translator.commands, command)
+			translator.afterOperand = true
 		case token.Kind == models.CommaToken:
+			if !afterOperand {
+				return nil, fmt.Errorf(
+					"unexpected token %+v with number #%d",
+					token,
+					tokenIndex,
+				)
+			}
+
 			err := translator.unwindStack(
 				func(tokenOnStack models.Token, ok bool) error {
 					if !ok {
@@ -97,6 +254,25 @@ func (translator *Translator) Translate(
 			if err != nil {
 				return nil, err
 			}
+
+			if tokenOnStack, ok := translator.stack.Pop(); ok {
+				translator.stack.Push(tokenOnStack)
+				if tokenOnStack.Kind == models.LeftBracketToken ||
+					tokenOnStack.Kind == models.LeftParenthesisToken {
+					translator.completeLazyArgument()
+
+					lastBracket := &translator.brackets[len(translator.brackets)-1]
+					lastBracket.elementCount++
+
+					translator.startLazyArgument(lastBracket.elementCount - 1)
+				}
+			}
+		default:
+			return nil, fmt.Errorf(
+				"unexpected token %+v with number #%d",
+				token,
+				tokenIndex,
+			)
 		}
 	}
 
@@ -108,11 +284,25 @@ func (translator *Translator) Translate(
 
 // Finalize ...
 func (translator *Translator) Finalize() ([]models.Command, error) {
+	if translator.afterFunctionName {
+		translator.completeVariable()
+		translator.afterFunctionName = false
+	}
+
+	// the operand of the last operator may be in the next part of the tokens,
+	// so it's checked only here
+	if tokenOnStack, ok := translator.stack.Pop(); ok {
+		translator.stack.Push(tokenOnStack)
+		if tokenOnStack.Kind.IsOperator() && !translator.afterOperand {
+			return nil, fmt.Errorf("missed operand for token %+v", tokenOnStack)
+		}
+	}
+
 	err := translator.unwindStack(func(tokenOnStack models.Token, ok bool) error {
 		if !ok {
 			return errStop
 		}
-		if tokenOnStack.Kind.IsParenthesis() {
+		if tokenOnStack.Kind.IsParenthesis() || tokenOnStack.Kind.IsBracket() {
 			return fmt.Errorf("missed pair for token %+v", tokenOnStack)
 		}
 
@@ -133,6 +323,87 @@ func (translator *Translator) addCommand(
 	translator.commands = append(translator.commands, command)
 }
 
+func (translator *Translator) pushOperator(token models.Token) {
+	// in this case, all errors will be processed inside the method
+	translator.unwindStack(func(tokenOnStack models.Token, ok bool) error {
+		if !ok {
+			return errStop
+		}
+		if !tokenOnStack.Kind.IsOperator() {
+			return errStopAndRestore
+		}
+		if tokenOnStack.Kind.Precedence() < token.Kind.Precedence() {
+			return errStopAndRestore
+		}
+
+		return nil
+	})
+
+	translator.stack.Push(token)
+}
+
+// it moves the function name from the stack to the commands as a variable,
+// e.g. the unit in "20 min", because it isn't followed by a parenthesis
+func (translator *Translator) completeVariable() {
+	kind := models.PushVariableCommand
+	if translator.isFunctionNameAfterNumber {
+		kind = models.PushUnitCommand
+	}
+
+	tokenOnStack, _ := translator.stack.Pop()
+	translator.addCommand(kind, tokenOnStack)
+	translator.afterOperand = true
+}
+
+// it moves the name of the called function (if any)
+// from the stack to the commands after its arguments are processed
+func (translator *Translator) completeFunctionCall(argumentCount int) {
+	tokenOnStack, ok := translator.stack.Pop()
+	if !ok {
+		return
+	}
+	if tokenOnStack.Kind != models.IdentifierToken {
+		translator.stack.Push(tokenOnStack)
+		return
+	}
+
+	translator.commands = append(translator.commands, models.Command{
+		Kind:          models.CallFunctionCommand,
+		Operand:       tokenOnStack.Value,
+		ArgumentCount: argumentCount,
+	})
+}
+
+// it starts an unevaluated argument with the specified index
+// if the current function call has one
+func (translator *Translator) startLazyArgument(argumentIndex int) {
+	lastBracket := translator.brackets[len(translator.brackets)-1]
+	if argumentIndex >= lastBracket.lazyArgumentCount {
+		return
+	}
+
+	translator.commands = append(translator.commands, models.Command{
+		Kind: models.StartExpressionCommand,
+	})
+}
+
+// it completes the current argument if it's unevaluated
+func (translator *Translator) completeLazyArgument() {
+	lastBracket := translator.brackets[len(translator.brackets)-1]
+	argumentIndex := lastBracket.elementCount - 1
+	if argumentIndex < 0 {
+		// the argument list is empty, but the start command is already added
+		argumentIndex = 0
+	}
+	if argumentIndex >= lastBracket.lazyArgumentCount {
+		return
+	}
+
+	translator.commands = append(translator.commands, models.Command{
+		Kind: models.EndExpressionCommand,
+	})
+}
+
 func (translator *Translator) unwindStack(checker stackChecker) error {
 	for {
 		tokenOnStack, ok := translator.stack.Pop()
@@ -30,6 +30,17 @@ func TestTranslator(test *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "string",
+			args: args{
+				tokens:    []models.Token{{Kind: models.StringToken, Value: "test"}},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushStringCommand, Operand: "test"},
+			},
+			wantErr: "",
+		},
 		{
 			name: "identifier",
 			args: args{
@@ -184,7 +195,11 @@ func TestTranslator(test *testing.T) {
 			},
 			wantCommands: []models.Command{
 				{Kind: models.PushNumberCommand, Operand: "23"},
-				{Kind: models.CallFunctionCommand, Operand: "test"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 1,
+				},
 			},
 			wantErr: "",
 		},
@@ -205,7 +220,11 @@ func TestTranslator(test *testing.T) {
 				{Kind: models.PushNumberCommand, Operand: "23"},
 				{Kind: models.PushNumberCommand, Operand: "42"},
 				{Kind: models.CallFunctionCommand, Operand: "+"},
-				{Kind: models.CallFunctionCommand, Operand: "test"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 1,
+				},
 			},
 			wantErr: "",
 		},
@@ -225,7 +244,11 @@ func TestTranslator(test *testing.T) {
 			wantCommands: []models.Command{
 				{Kind: models.PushNumberCommand, Operand: "23"},
 				{Kind: models.PushNumberCommand, Operand: "42"},
-				{Kind: models.CallFunctionCommand, Operand: "test"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 2,
+				},
 			},
 			wantErr: "",
 		},
@@ -253,7 +276,323 @@ func TestTranslator(test *testing.T) {
 				{Kind: models.PushNumberCommand, Operand: "23"},
 				{Kind: models.PushNumberCommand, Operand: "42"},
 				{Kind: models.CallFunctionCommand, Operand: "-"},
-				{Kind: models.CallFunctionCommand, Operand: "test"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 2,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "function call with a parenthesized argument",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+				},
+				functions: models.FunctionNameGroup{"test": {}},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.PushNumberCommand, Operand: "23"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 2,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "function call with unevaluated arguments",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.IdentifierToken, Value: "x"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.IdentifierToken, Value: "x"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+				},
+				functions: models.FunctionNameGroup{
+					"test": {LazyArgumentCount: 2},
+				},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.StartExpressionCommand},
+				{Kind: models.PushVariableCommand, Operand: "x"},
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.CallFunctionCommand, Operand: "+"},
+				{Kind: models.EndExpressionCommand},
+				{Kind: models.StartExpressionCommand},
+				{Kind: models.PushVariableCommand, Operand: "x"},
+				{Kind: models.EndExpressionCommand},
+				{Kind: models.PushNumberCommand, Operand: "23"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 3,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "function call with fewer arguments than unevaluated ones",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+				},
+				functions: models.FunctionNameGroup{
+					"test": {LazyArgumentCount: 2},
+				},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.StartExpressionCommand},
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.EndExpressionCommand},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 1,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "function name without parentheses",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "min"},
+					{Kind: models.SlashToken, Value: "/"},
+					{Kind: models.NumberToken, Value: "2"},
+				},
+				functions: models.FunctionNameGroup{"min": {}},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushVariableCommand, Operand: "min"},
+				{Kind: models.PushNumberCommand, Operand: "2"},
+				{Kind: models.CallFunctionCommand, Operand: "/"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "function call with the following operator",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+					{Kind: models.AsteriskToken, Value: "*"},
+					{Kind: models.NumberToken, Value: "23"},
+				},
+				functions: models.FunctionNameGroup{"test": {}},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 1,
+				},
+				{Kind: models.PushNumberCommand, Operand: "23"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "implicit multiplication",
+			args: args{
+				// 3 km / 20 min
+				tokens: []models.Token{
+					{Kind: models.NumberToken, Value: "3"},
+					{Kind: models.IdentifierToken, Value: "km"},
+					{Kind: models.SlashToken, Value: "/"},
+					{Kind: models.NumberToken, Value: "20"},
+					{Kind: models.IdentifierToken, Value: "min"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "3"},
+				{Kind: models.PushUnitCommand, Operand: "km"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
+				{Kind: models.PushNumberCommand, Operand: "20"},
+				{Kind: models.PushUnitCommand, Operand: "min"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
+				{Kind: models.CallFunctionCommand, Operand: "/"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "implicit multiplication with exponentiation",
+			args: args{
+				// 2 m^2
+				tokens: []models.Token{
+					{Kind: models.NumberToken, Value: "2"},
+					{Kind: models.IdentifierToken, Value: "m"},
+					{Kind: models.ExponentiationToken, Value: "^"},
+					{Kind: models.NumberToken, Value: "2"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "2"},
+				{Kind: models.PushUnitCommand, Operand: "m"},
+				{Kind: models.PushNumberCommand, Operand: "2"},
+				{Kind: models.CallFunctionCommand, Operand: "^"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "index with a complex expression",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightBracketToken, Value: "]"},
+					{Kind: models.AsteriskToken, Value: "*"},
+					{Kind: models.NumberToken, Value: "42"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushVariableCommand, Operand: "test"},
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.PushNumberCommand, Operand: "23"},
+				{Kind: models.CallFunctionCommand, Operand: "+"},
+				{Kind: models.IndexCommand, Operand: ""},
+				{Kind: models.PushNumberCommand, Operand: "42"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "few indexes",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightBracketToken, Value: "]"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushVariableCommand, Operand: "test"},
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.IndexCommand, Operand: ""},
+				{Kind: models.PushNumberCommand, Operand: "23"},
+				{Kind: models.IndexCommand, Operand: ""},
+			},
+			wantErr: "",
+		},
+		{
+			name: "index with a function call",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.IdentifierToken, Value: "floor"},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: models.FunctionNameGroup{"floor": {}},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushVariableCommand, Operand: "test"},
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "floor",
+					ArgumentCount: 1,
+				},
+				{Kind: models.IndexCommand, Operand: ""},
+			},
+			wantErr: "",
+		},
+		{
+			name: "array literal",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.NumberToken, Value: "42"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.NumberToken, Value: "5"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: models.FunctionNameGroup{"test": {}},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.PushNumberCommand, Operand: "23"},
+				{Kind: models.CallFunctionCommand, Operand: "+"},
+				{Kind: models.PushNumberCommand, Operand: "42"},
+				{Kind: models.PushNumberCommand, Operand: "5"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 2,
+				},
+				{Kind: models.MakeArrayCommand, Operand: "2"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "nested array literals",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightBracketToken, Value: "]"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.RightBracketToken, Value: "]"},
+					{Kind: models.RightBracketToken, Value: "]"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "0"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "12"},
+				{Kind: models.MakeArrayCommand, Operand: "1"},
+				{Kind: models.MakeArrayCommand, Operand: "0"},
+				{Kind: models.MakeArrayCommand, Operand: "2"},
+				{Kind: models.PushNumberCommand, Operand: "0"},
+				{Kind: models.IndexCommand, Operand: ""},
 			},
 			wantErr: "",
 		},
@@ -288,7 +627,7 @@ func TestTranslator(test *testing.T) {
 				functions: models.FunctionNameGroup{"test": {}},
 			},
 			wantCommands: nil,
-			wantErr:      "missed pair for token {Kind:9 Value:)} with number #4",
+			wantErr:      "missed pair for token {Kind:10 Value:,} with number #2",
 		},
 		{
 			name: "missed function name and left parenthesis in a function call",
@@ -304,6 +643,124 @@ func TestTranslator(test *testing.T) {
 			wantCommands: nil,
 			wantErr:      "missed pair for token {Kind:10 Value:,} with number #1",
 		},
+		{
+			name: "missed left bracket",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "missed pair for token {Kind:13 Value:]} with number #3",
+		},
+		{
+			name: "mismatched parenthesis and bracket",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "missed pair for token {Kind:9 Value:)} with number #3",
+		},
+		{
+			name: "empty element in an array literal",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "unexpected token {Kind:10 Value:,} with number #3",
+		},
+		{
+			name: "trailing comma in an array literal",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "unexpected token {Kind:13 Value:]} with number #3",
+		},
+		{
+			name: "index with few expressions",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.CommaToken, Value: ","},
+					{Kind: models.NumberToken, Value: "23"},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr: "index should be a single expression " +
+				"for token {Kind:13 Value:]} with number #5",
+		},
+		{
+			name: "empty index",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.RightBracketToken, Value: "]"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr: "index should be a single expression " +
+				"for token {Kind:13 Value:]} with number #2",
+		},
+		{
+			name: "missed right bracket",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.IdentifierToken, Value: "test"},
+					{Kind: models.LeftBracketToken, Value: "["},
+					{Kind: models.NumberToken, Value: "12"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushVariableCommand, Operand: "test"},
+				{Kind: models.PushNumberCommand, Operand: "12"},
+			},
+			wantErr: "missed pair for token {Kind:12 Value:[}",
+		},
+		{
+			name: "unexpected token",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.TokenKind(100), Value: "test"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "unexpected token {Kind:100 Value:test} with number #2",
+		},
 		{
 			name: "missed right parenthesis",
 			args: args{
@@ -324,6 +781,48 @@ func TestTranslator(test *testing.T) {
 			},
 			wantErr: "missed pair for token {Kind:8 Value:(}",
 		},
+		{
+			name: "missed left operand",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.AsteriskToken, Value: "*"},
+					{Kind: models.NumberToken, Value: "23"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "unexpected token {Kind:2 Value:*} with number #2",
+		},
+		{
+			name: "missed right operand before the right parenthesis",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.LeftParenthesisToken, Value: "("},
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+					{Kind: models.RightParenthesisToken, Value: ")"},
+				},
+				functions: nil,
+			},
+			wantCommands: nil,
+			wantErr:      "unexpected token {Kind:9 Value:)} with number #3",
+		},
+		{
+			name: "missed right operand at the end",
+			args: args{
+				tokens: []models.Token{
+					{Kind: models.NumberToken, Value: "12"},
+					{Kind: models.PlusToken, Value: "+"},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "12"},
+			},
+			wantErr: "missed operand for token {Kind:0 Value:+}",
+		},
 	}
 	for _, testCase := range testsCases {
 		test.Run(testCase.name, func(test *testing.T) {
@@ -405,7 +904,47 @@ func TestTranslator_withSequentialCalls(test *testing.T) {
 			},
 			wantCommands: []models.Command{
 				{Kind: models.PushNumberCommand, Operand: "23"},
-				{Kind: models.CallFunctionCommand, Operand: "test"},
+				{
+					Kind:          models.CallFunctionCommand,
+					Operand:       "test",
+					ArgumentCount: 1,
+				},
+			},
+		},
+		{
+			name: "implicit multiplication",
+			args: args{
+				tokenGroups: [][]models.Token{
+					{
+						{Kind: models.NumberToken, Value: "3"},
+					},
+					{
+						{Kind: models.IdentifierToken, Value: "km"},
+					},
+				},
+				functions: nil,
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "3"},
+				{Kind: models.PushUnitCommand, Operand: "km"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
+			},
+		},
+		{
+			name: "function name without parentheses",
+			args: args{
+				tokenGroups: [][]models.Token{
+					{
+						{Kind: models.NumberToken, Value: "20"},
+						{Kind: models.IdentifierToken, Value: "min"},
+					},
+				},
+				functions: models.FunctionNameGroup{"min": {}},
+			},
+			wantCommands: []models.Command{
+				{Kind: models.PushNumberCommand, Operand: "20"},
+				{Kind: models.PushUnitCommand, Operand: "min"},
+				{Kind: models.CallFunctionCommand, Operand: "*"},
 			},
 		},
 	}
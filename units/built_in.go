@@ -0,0 +1,104 @@
+package units
+
+import "github.com/irenicaa/go-calculator/v2/models"
+
+type prefix struct {
+	symbol   string
+	factor   float64
+	isBinary bool
+}
+
+// two-letter prefixes go first
+var prefixes = []prefix{
+	{symbol: "da", factor: 1e1},
+	{symbol: "Ki", factor: 1 << 10, isBinary: true},
+	{symbol: "Mi", factor: 1 << 20, isBinary: true},
+	{symbol: "Gi", factor: 1 << 30, isBinary: true},
+	{symbol: "Ti", factor: 1 << 40, isBinary: true},
+	{symbol: "Pi", factor: 1 << 50, isBinary: true},
+	{symbol: "Ei", factor: 1 << 60, isBinary: true},
+	{symbol: "Y", factor: 1e24},
+	{symbol: "Z", factor: 1e21},
+	{symbol: "E", factor: 1e18},
+	{symbol: "P", factor: 1e15},
+	{symbol: "T", factor: 1e12},
+	{symbol: "G", factor: 1e9},
+	{symbol: "M", factor: 1e6},
+	{symbol: "k", factor: 1e3},
+	{symbol: "h", factor: 1e2},
+	{symbol: "d", factor: 1e-1},
+	{symbol: "c", factor: 1e-2},
+	{symbol: "m", factor: 1e-3},
+	{symbol: "u", factor: 1e-6},
+	{symbol: "µ", factor: 1e-6},
+	{symbol: "n", factor: 1e-9},
+	{symbol: "p", factor: 1e-12},
+	{symbol: "f", factor: 1e-15},
+	{symbol: "a", factor: 1e-18},
+	{symbol: "z", factor: 1e-21},
+	{symbol: "y", factor: 1e-24},
+}
+
+var (
+	length      = models.NewBaseDimension(models.LengthDimension)
+	mass        = models.NewBaseDimension(models.MassDimension)
+	time        = models.NewBaseDimension(models.TimeDimension)
+	current     = models.NewBaseDimension(models.CurrentDimension)
+	temperature = models.NewBaseDimension(models.TemperatureDimension)
+	amount      = models.NewBaseDimension(models.AmountDimension)
+	luminosity  = models.NewBaseDimension(models.LuminousIntensityDimension)
+	information = models.NewBaseDimension(models.InformationDimension)
+
+	frequency = models.Dimension{}.Divide(time)
+	force     = mass.Multiply(length).Divide(time).Divide(time)
+	pressure  = force.Divide(length).Divide(length)
+	energy    = force.Multiply(length)
+	powerUnit = energy.Divide(time)
+	charge    = current.Multiply(time)
+	voltage   = powerUnit.Divide(current)
+	volume    = length.Multiply(length).Multiply(length)
+)
+
+// BuiltInUnits ...
+var BuiltInUnits = Registry{
+	// SI base units
+	"m":   {Factor: 1, Dimension: length, Prefixes: DecimalPrefixes},
+	"g":   {Factor: 1e-3, Dimension: mass, Prefixes: DecimalPrefixes},
+	"s":   {Factor: 1, Dimension: time, Prefixes: DecimalPrefixes},
+	"A":   {Factor: 1, Dimension: current, Prefixes: DecimalPrefixes},
+	"K":   {Factor: 1, Dimension: temperature, Prefixes: DecimalPrefixes},
+	"mol": {Factor: 1, Dimension: amount, Prefixes: DecimalPrefixes},
+	"cd":  {Factor: 1, Dimension: luminosity, Prefixes: DecimalPrefixes},
+
+	// SI derived units
+	"Hz":  {Factor: 1, Dimension: frequency, Prefixes: DecimalPrefixes},
+	"N":   {Factor: 1, Dimension: force, Prefixes: DecimalPrefixes},
+	"Pa":  {Factor: 1, Dimension: pressure, Prefixes: DecimalPrefixes},
+	"J":   {Factor: 1, Dimension: energy, Prefixes: DecimalPrefixes},
+	"W":   {Factor: 1, Dimension: powerUnit, Prefixes: DecimalPrefixes},
+	"C":   {Factor: 1, Dimension: charge, Prefixes: DecimalPrefixes},
+	"V":   {Factor: 1, Dimension: voltage, Prefixes: DecimalPrefixes},
+	"ohm": {Factor: 1, Dimension: voltage.Divide(current), Prefixes: DecimalPrefixes},
+	"L":   {Factor: 1e-3, Dimension: volume, Prefixes: DecimalPrefixes},
+
+	// other units of length and mass
+	"in": {Factor: 0.0254, Dimension: length},
+	"ft": {Factor: 0.3048, Dimension: length},
+	"yd": {Factor: 0.9144, Dimension: length},
+	"mi": {Factor: 1609.344, Dimension: length},
+	"t":  {Factor: 1e3, Dimension: mass},
+	"lb": {Factor: 0.45359237, Dimension: mass},
+
+	// time
+	"min":  {Factor: 60, Dimension: time},
+	"h":    {Factor: 3600, Dimension: time},
+	"d":    {Factor: 86400, Dimension: time},
+	"week": {Factor: 604800, Dimension: time},
+	// the Julian year
+	"year": {Factor: 31557600, Dimension: time},
+
+	// data sizes
+	"B":   {Factor: 1, Dimension: information, Prefixes: AllPrefixes},
+	"bit": {Factor: 0.125, Dimension: information, Prefixes: AllPrefixes},
+	"b":   {Factor: 0.125, Dimension: information, Prefixes: AllPrefixes},
+}
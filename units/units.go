@@ -0,0 +1,141 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+)
+
+// PrefixKind ...
+type PrefixKind int
+
+// ...
+const (
+	NoPrefixes PrefixKind = iota
+	DecimalPrefixes
+	// decimal and binary ones
+	AllPrefixes
+)
+
+// Definition is a plain value.
+type Definition struct {
+	Factor    float64 // the value of the unit in the base units
+	Dimension models.Dimension
+	Prefixes  PrefixKind
+}
+
+// Registry is safe for concurrent reading only, e.g. BuiltInUnits
+// shouldn't be changed while code is calculated.
+type Registry map[string]Definition
+
+// Lookup finds the unit by its name with an optional prefix.
+func (registry Registry) Lookup(name string) (models.Unit, bool) {
+	if definition, ok := registry[name]; ok {
+		return newUnit(name, definition.Factor, definition), true
+	}
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(name, prefix.symbol) {
+			continue
+		}
+
+		definition, ok := registry[strings.TrimPrefix(name, prefix.symbol)]
+		if !ok || definition.Prefixes == NoPrefixes ||
+			(prefix.isBinary && definition.Prefixes != AllPrefixes) {
+			continue
+		}
+
+		return newUnit(name, prefix.factor*definition.Factor, definition), true
+	}
+
+	return models.Unit{}, false
+}
+
+// Parse parses the product of units, e.g. "kg*m/s^2";
+// exponents should be integers.
+func (registry Registry) Parse(text string) (models.Unit, error) {
+	text = strings.Join(strings.Fields(text), "")
+	unit := models.Unit{Name: text, Factor: 1}
+	sign := 1
+	for termIndex := 0; ; termIndex++ {
+		term := text
+		separatorIndex := strings.IndexAny(text, "*/")
+		if separatorIndex != -1 {
+			term = text[:separatorIndex]
+		}
+
+		name, exponent, err := parseTerm(term)
+		if err != nil {
+			return models.Unit{}, fmt.Errorf("term #%d: %w", termIndex, err)
+		}
+
+		termUnit, ok := registry.Lookup(name)
+		if !ok {
+			return models.Unit{}, fmt.Errorf("term #%d: unknown unit %q", termIndex, name)
+		}
+
+		exponent *= sign
+		// the exponent is an integer, so there is no error
+		dimension, _ := termUnit.Dimension.Power(float64(exponent))
+		unit.Dimension = unit.Dimension.Multiply(dimension)
+		unit.Factor *= power(termUnit.Factor, exponent)
+
+		if separatorIndex == -1 {
+			break
+		}
+
+		sign = 1
+		if text[separatorIndex] == '/' {
+			sign = -1
+		}
+		text = text[separatorIndex+1:]
+	}
+
+	return unit, nil
+}
+
+func newUnit(name string, factor float64, definition Definition) models.Unit {
+	return models.Unit{Name: name, Factor: factor, Dimension: definition.Dimension}
+}
+
+func parseTerm(term string) (name string, exponent int, err error) {
+	parts := strings.SplitN(term, "^", 2)
+	name = parts[0]
+	if name == "" {
+		return "", 0, errors.New("empty unit")
+	}
+	if strings.IndexFunc(name, isNotNameSymbol) != -1 {
+		return "", 0, fmt.Errorf("incorrect unit %q", name)
+	}
+	if len(parts) == 1 {
+		return name, 1, nil
+	}
+
+	exponent, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("incorrect exponent: %w", err)
+	}
+
+	return name, exponent, nil
+}
+
+func isNotNameSymbol(symbol rune) bool {
+	return !unicode.IsLetter(symbol) && !unicode.IsDigit(symbol) && symbol != '_'
+}
+
+func power(base float64, exponent int) float64 {
+	if exponent < 0 {
+		return 1 / power(base, -exponent)
+	}
+
+	result := 1.0
+	for index := 0; index < exponent; index++ {
+		result *= base
+	}
+
+	return result
+}
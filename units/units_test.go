@@ -0,0 +1,166 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRegistry = Registry{
+	"m":   {Factor: 1, Dimension: length, Prefixes: DecimalPrefixes},
+	"s":   {Factor: 1, Dimension: time, Prefixes: DecimalPrefixes},
+	"min": {Factor: 60, Dimension: time},
+	"B":   {Factor: 1, Dimension: information, Prefixes: AllPrefixes},
+}
+
+func TestRegistry_Lookup(test *testing.T) {
+	type args struct {
+		name string
+	}
+
+	testsCases := []struct {
+		name     string
+		args     args
+		wantUnit models.Unit
+		wantOk   bool
+	}{
+		{
+			name:     "without a prefix",
+			args:     args{name: "min"},
+			wantUnit: models.Unit{Name: "min", Factor: 60, Dimension: time},
+			wantOk:   true,
+		},
+		{
+			name:     "with a decimal prefix",
+			args:     args{name: "km"},
+			wantUnit: models.Unit{Name: "km", Factor: 1e3, Dimension: length},
+			wantOk:   true,
+		},
+		{
+			name:     "with a two-letter decimal prefix",
+			args:     args{name: "dam"},
+			wantUnit: models.Unit{Name: "dam", Factor: 10, Dimension: length},
+			wantOk:   true,
+		},
+		{
+			name: "with a binary prefix",
+			args: args{name: "GiB"},
+			wantUnit: models.Unit{
+				Name:      "GiB",
+				Factor:    1 << 30,
+				Dimension: information,
+			},
+			wantOk: true,
+		},
+		{
+			name:     "with a binary prefix for a decimal unit",
+			args:     args{name: "Kim"},
+			wantUnit: models.Unit{},
+			wantOk:   false,
+		},
+		{
+			name:     "with a prefix for a unit without prefixes",
+			args:     args{name: "kmin"},
+			wantUnit: models.Unit{},
+			wantOk:   false,
+		},
+		{
+			name:     "unknown",
+			args:     args{name: "unknown"},
+			wantUnit: models.Unit{},
+			wantOk:   false,
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotUnit, gotOk := testRegistry.Lookup(testCase.args.name)
+
+			assert.Equal(test, testCase.wantUnit, gotUnit)
+			assert.Equal(test, testCase.wantOk, gotOk)
+		})
+	}
+}
+
+func TestRegistry_Parse(test *testing.T) {
+	type args struct {
+		text string
+	}
+
+	testsCases := []struct {
+		name     string
+		args     args
+		wantUnit models.Unit
+		wantErr  string
+	}{
+		{
+			name: "success with a single unit",
+			args: args{text: " km "},
+			wantUnit: models.Unit{
+				Name:      "km",
+				Factor:    1e3,
+				Dimension: length,
+			},
+			wantErr: "",
+		},
+		{
+			name: "success with a quotient",
+			args: args{text: "MB / s"},
+			wantUnit: models.Unit{
+				Name:      "MB/s",
+				Factor:    1e6,
+				Dimension: information.Divide(time),
+			},
+			wantErr: "",
+		},
+		{
+			name: "success with exponents",
+			args: args{text: "km^2*min/s^-1/min^2"},
+			wantUnit: models.Unit{
+				Name:      "km^2*min/s^-1/min^2",
+				Factor:    1e6 / 60,
+				Dimension: length.Multiply(length),
+			},
+			wantErr: "",
+		},
+		{
+			name:     "error with an empty unit",
+			args:     args{text: "m//s"},
+			wantUnit: models.Unit{},
+			wantErr:  "term #1: empty unit",
+		},
+		{
+			name:     "error with an incorrect unit",
+			args:     args{text: "m+s"},
+			wantUnit: models.Unit{},
+			wantErr:  "term #0: incorrect unit \"m+s\"",
+		},
+		{
+			name:     "error with an exponent",
+			args:     args{text: "m^x"},
+			wantUnit: models.Unit{},
+			wantErr: "term #0: incorrect exponent: " +
+				"strconv.Atoi: parsing \"x\": invalid syntax",
+		},
+		{
+			name:     "error with an unknown unit",
+			args:     args{text: "m/unknown"},
+			wantUnit: models.Unit{},
+			wantErr:  "term #1: unknown unit \"unknown\"",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			gotUnit, gotErr := testRegistry.Parse(testCase.args.text)
+
+			assert.Equal(test, testCase.wantUnit.Name, gotUnit.Name)
+			assert.InDelta(test, testCase.wantUnit.Factor, gotUnit.Factor, 1e-6)
+			assert.Equal(test, testCase.wantUnit.Dimension, gotUnit.Dimension)
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
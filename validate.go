@@ -0,0 +1,55 @@
+package calculator
+
+// Validate checks the statement against the options of the interpreter
+// without evaluating it, so it doesn't change the variables. It returns
+// all the violations, and the error is returned only for incorrect code.
+//
+// The variables of the import statement are known only after the module
+// is evaluated, so their violations aren't reported here, although
+// Interpret returns them.
+func (interpreter Interpreter) Validate(input string) ([]Violation, error) {
+	statement, err := translateStatement(input, interpreter.allFunctions())
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	if statement.variable != "" {
+		interpreter.lock.RLock()
+		violations, err = interpreter.findAssignmentViolations(
+			statement.variable,
+			statement.isConstant,
+		)
+		interpreter.lock.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if statement.modeName != "" {
+		violations = interpreter.options.findModeViolations(statement.modeName)
+	}
+
+	violations = append(
+		violations,
+		interpreter.options.findCommandViolations(statement.commands)...,
+	)
+	return violations, nil
+}
+
+// Validate checks the code against the options of the calculator
+// without evaluating it; it doesn't affect code passed to Calculate.
+// It returns all the violations, and the error is returned only
+// for incorrect code.
+func (calculator *Calculator) Validate(code string) ([]Violation, error) {
+	tokens, err := tokenizeCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := translateTokens(tokens, calculator.functions)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculator.options.findCommandViolations(commands), nil
+}
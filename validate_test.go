@@ -0,0 +1,185 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/irenicaa/go-calculator/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpreter_Validate(test *testing.T) {
+	type args struct {
+		options []Option
+		input   string
+	}
+
+	testsCases := []struct {
+		name           string
+		args           args
+		wantViolations []Violation
+		wantErr        string
+	}{
+		{
+			name: "without violations",
+			args: args{
+				options: []Option{AllowFunctions("sqrt"), ReadOnlyVariables("pi")},
+				input:   "x = sqrt(pi) // abs(x)",
+			},
+			wantViolations: nil,
+			wantErr:        "",
+		},
+		{
+			name: "with the functions",
+			args: args{
+				options: []Option{AllowFunctions("sqrt")},
+				input:   "x[abs(0)] = max(sqrt(2), abs(1), min(1, 2)) to m",
+			},
+			wantViolations: []Violation{
+				{Kind: ForbiddenFunctionViolation, Name: "abs"},
+				// functions are called after their arguments
+				{Kind: ForbiddenFunctionViolation, Name: "min"},
+				{Kind: ForbiddenFunctionViolation, Name: "max"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "with the print statement",
+			args: args{
+				options: []Option{AllowFunctions()},
+				input:   "print \"x = \", abs(x), sqrt(x)",
+			},
+			wantViolations: []Violation{
+				{Kind: ForbiddenFunctionViolation, Name: "abs"},
+				{Kind: ForbiddenFunctionViolation, Name: "sqrt"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "with the mode statement",
+			args: args{
+				options: []Option{AllowFunctions(), ForbidAssignment()},
+				input:   "mode deg",
+			},
+			wantViolations: []Violation{
+				{Kind: ForbiddenModeViolation, Name: "deg"},
+			},
+			wantErr: "",
+		},
+		{
+			// variables of the module are unknown without its evaluation
+			name: "with the import statement",
+			args: args{
+				options: []Option{AllowFunctions(), ForbidAssignment()},
+				input:   "import \"geometry.code\" as geo",
+			},
+			wantViolations: nil,
+			wantErr:        "",
+		},
+		{
+			name: "with the read-only variable",
+			args: args{
+				options: []Option{AllowFunctions(), ReadOnlyVariables("pi")},
+				input:   "pi = abs(3)",
+			},
+			wantViolations: []Violation{
+				{Kind: ReadOnlyVariableViolation, Name: "pi"},
+				{Kind: ForbiddenFunctionViolation, Name: "abs"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "with the forbidden assignment",
+			args: args{
+				options: []Option{ForbidAssignment(), ReadOnlyVariables("pi")},
+				input:   "pi[1] = 3",
+			},
+			wantViolations: []Violation{
+				{Kind: ForbiddenAssignmentViolation, Name: "pi"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "with the constant",
+			args: args{
+				options: []Option{
+					Constants(models.ConstantGroup{"pi": models.NewNumber(3)}),
+				},
+				input: "const pi = 4",
+			},
+			wantViolations: []Violation{
+				{Kind: ConstantAssignmentViolation, Name: "pi"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "error with the declaration of the element",
+			args: args{
+				options: nil,
+				input:   "const x[0] = 4",
+			},
+			wantViolations: nil,
+			wantErr: "unable to declare the constant: " +
+				"constant can't be declared by its elements",
+		},
+		{
+			name: "error with the code",
+			args: args{
+				options: []Option{AllowFunctions()},
+				input:   "x = abs(3",
+			},
+			wantViolations: nil,
+			wantErr: "unable to finalize the translator: " +
+				"missed pair for token {Kind:8 Value:(}",
+		},
+	}
+	for _, testCase := range testsCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			interpreter := NewInterpreter(
+				models.VariableGroup{},
+				BuiltInFunctions,
+				testCase.args.options...,
+			)
+			gotViolations, gotErr := interpreter.Validate(testCase.args.input)
+
+			assert.Equal(test, testCase.wantViolations, gotViolations)
+			assert.Equal(test, models.VariableGroup{}, interpreter.Variables())
+			if testCase.wantErr == "" {
+				assert.NoError(test, gotErr)
+			} else {
+				assert.EqualError(test, gotErr, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalculator_Validate(test *testing.T) {
+	calculator := NewCalculator(nil, BuiltInFunctions, AllowFunctions("sqrt"))
+	gotViolations, gotErr := calculator.Validate("sqrt(abs(x) + abs(y)) + max(x, y)")
+
+	assert.Equal(test, []Violation{
+		{Kind: ForbiddenFunctionViolation, Name: "abs"},
+		{Kind: ForbiddenFunctionViolation, Name: "max"},
+	}, gotViolations)
+	assert.NoError(test, gotErr)
+}
+
+func TestViolation_Error(test *testing.T) {
+	violations := []Violation{
+		{Kind: ForbiddenFunctionViolation, Name: "abs"},
+		{Kind: ReadOnlyVariableViolation, Name: "pi"},
+		{Kind: ForbiddenAssignmentViolation, Name: "x"},
+		{Kind: ConstantAssignmentViolation, Name: "pi"},
+		{Kind: ForbiddenModeViolation, Name: "deg"},
+	}
+	wantMessages := []string{
+		"function abs isn't allowed",
+		"variable pi is read-only",
+		"assignment of variable x is forbidden",
+		"constant pi can't be reassigned",
+		"change of the mode to deg is forbidden",
+	}
+
+	for violationIndex, violation := range violations {
+		assert.Equal(test, wantMessages[violationIndex], violation.Error())
+	}
+}